@@ -2,12 +2,15 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/flash-cards-vocab/backend/entity"
 	"github.com/google/uuid"
 )
 
 var ErrCardNotFound = errors.New("Card not found")
+var ErrCardMnemonicNotFound = errors.New("Card mnemonic not found")
+var ErrCardMnemonicExists = errors.New("Card mnemonic already exists")
 
 type CardRepository interface {
 	CreateSingleCard(card entity.Card) error
@@ -17,6 +20,33 @@ type CardRepository interface {
 	KnowCard(collectionId, cardId, userId uuid.UUID) error
 	DontKnowCard(collectionId, cardId, userId uuid.UUID) error
 	GetUserCardsStatistics(userId uuid.UUID) (*entity.UserCardStatistics, error)
-	GetUserCardsByWord(word string, userId uuid.UUID, limit, offset int) ([]*entity.CardWithOccurence, error)
-	GetGlobalCardsByWord(word string, userId uuid.UUID, limit, offset int) ([]*entity.CardWithOccurence, error)
+	GetUserCardsByWord(word string, userId uuid.UUID, filter entity.SearchFilter, limit, offset int) ([]*entity.CardWithOccurence, error)
+	GetGlobalCardsByWord(word string, userId uuid.UUID, filter entity.SearchFilter, limit, offset int) ([]*entity.CardWithOccurence, error)
+	GetAuthorCardsByWords(authorId uuid.UUID, words []string) ([]*entity.Card, error)
+	GetCardById(id uuid.UUID) (*entity.Card, error)
+	GetSimilarCards(word, language string, lexemeId, canonicalCardId uuid.UUID, limit int) ([]*entity.CardWithOccurence, error)
+	GetCardsByIds(ids []uuid.UUID) ([]*entity.Card, error)
+	GetCardsLinkingTo(ids []uuid.UUID) ([]*entity.Card, error)
+	IsCardInCollection(collectionId, cardId uuid.UUID) (bool, error)
+	IsCardSharedWithOtherAuthors(cardId, authorId uuid.UUID) (bool, error)
+	UpdateCard(card entity.Card, editorId uuid.UUID) error
+	ForkCard(collectionId, cardId uuid.UUID, card entity.Card) (*entity.Card, error)
+	GetCardRevisions(cardId uuid.UUID) ([]*entity.CardRevision, error)
+	GetCardMetrics(id uuid.UUID) (*entity.CardMetrics, error)
+	GetCardUserMetrics(id, userId uuid.UUID) (*entity.CardUserMetrics, error)
+	ToggleCardLike(id, userId uuid.UUID) error
+	ToggleCardDislike(id, userId uuid.UUID) error
+	GetCollectionCardsWithoutAudio(collectionId, authorId uuid.UUID) ([]*entity.Card, error)
+	SetCardAudio(id uuid.UUID, audioUrl string) error
+	GetCardNote(cardId, userId uuid.UUID) (*entity.CardNote, error)
+	SaveCardNote(note entity.CardNote) (*entity.CardNote, error)
+	DeleteCardNote(cardId, userId uuid.UUID) error
+	CountUserMnemonicsSince(userId uuid.UUID, since time.Time) (int, error)
+	HasUserCardMnemonic(cardId, userId uuid.UUID) (bool, error)
+	CreateCardMnemonic(mnemonic entity.CardMnemonic) (*entity.CardMnemonic, error)
+	GetCardMnemonic(id, userId uuid.UUID) (*entity.CardMnemonic, error)
+	GetCardMnemonics(cardId, userId uuid.UUID, limit, offset int) ([]*entity.CardMnemonic, int, error)
+	GetTopCardMnemonics(cardIds []uuid.UUID, userId uuid.UUID, perCard int) ([]*entity.CardMnemonic, error)
+	VoteCardMnemonic(id, userId uuid.UUID, vote int) error
+	DeleteCardMnemonic(id uuid.UUID) error
 }
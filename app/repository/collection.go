@@ -11,11 +11,15 @@ var ErrCollectionNotFound = errors.New("loan request not found")
 var ErrCollectionUserMetricsNotFound = errors.New("collection user metrics not found")
 var ErrCollectionUserProgressNotFound = errors.New("collection user progress not found")
 var ErrCollectionMetricsNotFound = errors.New("collection metrics not found")
+var ErrCollectionReviewNotFound = errors.New("collection review not found")
 
 type CollectionRepository interface {
 	GetMyCollections(userId uuid.UUID) ([]*entity.Collection, error)
 	GetTotalCardsInCollection(collection_id uuid.UUID) (int, error)
-	GetRecommendedCollectionsPreview(userId uuid.UUID, limit, offset int) ([]*entity.Collection, error)
+	GetCollectionCardLevels(collectionId uuid.UUID) ([]*entity.LevelCount, error)
+	GetCollectionCardsProgress(collectionId, userId uuid.UUID) ([]*entity.CardUserProgress, error)
+	GetRecommendationCandidates(userId uuid.UUID, query entity.RecommendationQuery, limit, offset int, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.RecommendationCandidate, error)
+	GetUserCollectionEngagements(userId uuid.UUID) ([]*entity.CollectionEngagement, error)
 	GetLikedCollectionsPreview(userId uuid.UUID) ([]*entity.Collection, error)
 	GetStarredCollectionsPreview(userId uuid.UUID) ([]*entity.Collection, error)
 	IsCollectionLikedOrDislikedByUser(id, userId uuid.UUID) (bool, bool, error)
@@ -27,9 +31,9 @@ type CollectionRepository interface {
 	CollectionLikeInteraction(id, userId uuid.UUID, isLiked bool) error
 	CollectionDislikeInteraction(id, userId uuid.UUID, isDisliked bool) error
 	ViewCollection(id, userId uuid.UUID) error
-	SearchCollectionByName(search string, userId uuid.UUID) ([]*entity.Collection, error)
+	SearchCollectionByName(search string, userId uuid.UUID, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.Collection, error)
 	UpdateCollection(collection entity.Collection) error
-	CreateCollectionWithCards(collection entity.Collection, cards []*entity.Card) (*entity.Collection, error)
+	CreateCollectionWithCards(collection entity.Collection, cards []*entity.Card, existingCardIds []uuid.UUID) (*entity.Collection, error)
 
 	GetCollectionMetrics(id uuid.UUID) (*entity.CollectionMetrics, error)
 	GetCollectionUserProgress(id, userId uuid.UUID) (*entity.CollectionUserProgress, error)
@@ -40,7 +44,19 @@ type CollectionRepository interface {
 	GetCollectionCards(collectionId, userId uuid.UUID, limit, offset int) (*entity.CardForUserPagination, error)
 	GetUserCollectionsStatistics(userId uuid.UUID) (*entity.UserCollectionStatistics, error)
 
-	SearchCollectionByNameForUnregistered(search string) ([]*entity.Collection, error)
+	SearchCollectionByNameForUnregistered(search string, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.Collection, error)
 	GetCollectionCardsForUnregistered(collectionId uuid.UUID, limit int, offset int) (*entity.CardForUserPagination, error)
-	GetRecommendedCollectionsPreviewForUnregistered(limit, offset int) ([]*entity.Collection, error)
+	GetRecommendedCollectionsPreviewForUnregistered(limit, offset int, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.Collection, error)
+
+	CreateCollectionEvent(id, userId uuid.UUID, eventType entity.CollectionEventType) error
+	DeleteCollectionEvents(id, userId uuid.UUID, eventType entity.CollectionEventType) error
+	RefreshTrendingCollections(window entity.TrendingWindow) error
+	GetTrendingCollections(window entity.TrendingWindow, limit, offset int) ([]*entity.Collection, error)
+
+	GetCollectionReview(collectionId, userId uuid.UUID) (*entity.CollectionReview, error)
+	GetCollectionReviewById(id uuid.UUID) (*entity.CollectionReview, error)
+	GetCollectionReviews(collectionId uuid.UUID, limit, offset int) ([]*entity.CollectionReview, int, error)
+	UpsertCollectionReview(collectionId, userId uuid.UUID, rating int, text string) (*entity.CollectionReview, error)
+	DeleteCollectionReview(collectionId, userId uuid.UUID) error
+	ReplyToCollectionReview(id uuid.UUID, reply string) error
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+var ErrFolderNotFound = errors.New("folder not found")
+
+type FolderRepository interface {
+	CreateFolder(folder entity.Folder) (*entity.Folder, error)
+	GetFolder(id, userId uuid.UUID) (*entity.Folder, error)
+	GetUserFolders(userId uuid.UUID) ([]*entity.Folder, error)
+	UpdateFolder(folder entity.Folder) error
+	DeleteFolder(id, userId uuid.UUID) error
+	AssignCollectionToFolder(userId, collectionId uuid.UUID, folderId *uuid.UUID) error
+	GetFolderCollections(userId uuid.UUID) ([]*entity.FolderCollection, error)
+}
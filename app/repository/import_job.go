@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+var ErrImportJobNotFound = errors.New("import job not found")
+
+type ImportJobRepository interface {
+	CreateImportJob(job entity.ImportJob) (*entity.ImportJob, error)
+	GetImportJob(id uuid.UUID) (*entity.ImportJob, error)
+	GetImportJobFile(id uuid.UUID) ([]byte, error)
+	GetUnfinishedImportJobs() ([]*entity.ImportJob, error)
+	// UpdateImportJob saves the status and the progress of a job that is not finished yet,
+	// it returns false when the job was finished in the meantime, e.g. cancelled by the user
+	UpdateImportJob(job entity.ImportJob) (bool, error)
+	CancelImportJob(id uuid.UUID) (bool, error)
+}
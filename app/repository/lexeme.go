@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+var ErrLexemeNotFound = errors.New("lexeme not found")
+
+type LexemeRepository interface {
+	GetLexeme(id uuid.UUID) (*entity.Lexeme, error)
+	GetLexemeByWord(word, language string) (*entity.Lexeme, error)
+	GetLexemesByWords(words []string) ([]*entity.Lexeme, error)
+	CreateLexemes(lexemes []*entity.Lexeme) (int, error)
+	GetUnlinkedCards(afterId uuid.UUID, limit int) ([]*entity.Card, error)
+	LinkCardsToLexeme(lexemeId uuid.UUID, cardIds []uuid.UUID) (int, error)
+	RefreshLexemes() (int, error)
+}
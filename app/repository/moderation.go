@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+var ErrReportNotFound = errors.New("report not found")
+var ErrReportTargetNotFound = errors.New("report target not found")
+
+type ModerationRepository interface {
+	GetReportTargetAuthor(targetType entity.ReportTargetType, targetId uuid.UUID) (uuid.UUID, error)
+	HasOpenReport(reporterId uuid.UUID, targetType entity.ReportTargetType, targetId uuid.UUID) (bool, error)
+	CreateReport(report entity.Report) (*entity.Report, error)
+	GetReport(id uuid.UUID) (*entity.Report, error)
+	GetReports(status entity.ReportStatus, limit, offset int) ([]*entity.Report, int, error)
+	ApplyModerationAction(report entity.Report, action entity.ModerationAction, moderatorId uuid.UUID, note string) error
+	GetUserWarnings(userId uuid.UUID) ([]*entity.UserWarning, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+var ErrUserExportNotFound = errors.New("user export not found")
+
+type UserExportRepository interface {
+	CreateUserExport(export entity.UserDataExport) (*entity.UserDataExport, error)
+	UpdateUserExport(export entity.UserDataExport) error
+	GetUserExport(id uuid.UUID) (*entity.UserDataExport, error)
+	GetUnfinishedUserExports() ([]*entity.UserDataExport, error)
+	GetUserAuthoredCollections(userId uuid.UUID) ([]*entity.UserDataCollection, error)
+	GetUserAuthoredCards(userId uuid.UUID) ([]*entity.Card, error)
+	GetUserFolders(userId uuid.UUID) ([]*entity.Folder, error)
+	GetUserFolderCollections(userId uuid.UUID) ([]*entity.UserDataFolderCollection, error)
+	GetUserCollectionMetrics(userId uuid.UUID) ([]*entity.CollectionUserMetrics, error)
+	GetUserCollectionProgress(userId uuid.UUID) ([]*entity.CollectionUserProgress, error)
+	GetUserCardProgress(userId uuid.UUID) ([]*entity.CardUserProgress, error)
+	GetUserCardNotes(userId uuid.UUID) ([]*entity.CardNote, error)
+	GetUserCardMnemonics(userId uuid.UUID) ([]*entity.CardMnemonic, error)
+	GetUserCardMetrics(userId uuid.UUID) ([]*entity.CardUserMetrics, error)
+}
@@ -0,0 +1,112 @@
+package card_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/flash-cards-vocab/backend/pkg/audio"
+	"github.com/flash-cards-vocab/backend/pkg/media"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// UploadCardAudio validates an uploaded or recorded pronunciation and stores it, the returned URL is set
+// as the audioUrl of a card when the collection is created or updated
+func (uc *usecase) UploadCardAudio(file io.Reader, filename string) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(file, audio.MaxSize+1))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidAudio, err)
+	}
+	info, err := checkAudio(data)
+	if err != nil {
+		return "", err
+	}
+	name := strings.TrimSuffix(filename, info.Format.Extension())
+	audioUrl, err := uc.uploadCardAudio(data, name, info.Format)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return "", fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return audioUrl, nil
+}
+
+// GenerateMissingAudio reads aloud the words of the cards the user authored in the collection that have no audio yet.
+// A card the speech provider fails on is reported and the other cards are still generated.
+func (uc *usecase) GenerateMissingAudio(collectionId, userId uuid.UUID) (*entity.CardAudioGeneration, error) {
+	if uc.ttsProvider == nil {
+		return nil, ErrSpeechUnavailable
+	}
+	collection, err := uc.collectionRepo.GetCollection(collectionId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if collection.AuthorId != userId {
+		return nil, ErrUnauthorized
+	}
+	cards, err := uc.cardRepo.GetCollectionCardsWithoutAudio(collectionId, userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+
+	result := &entity.CardAudioGeneration{Failed: []string{}}
+	for _, card := range cards {
+		language := card.SourceLanguage
+		if language == "" {
+			language = collection.SourceLanguage
+		}
+		err = uc.generateCardAudio(card, language)
+		if err != nil {
+			logrus.Warnf("failed to generate audio of card %s: %v", card.Id, err)
+			result.Failed = append(result.Failed, card.Word)
+			continue
+		}
+		result.Generated++
+	}
+	return result, nil
+}
+
+func (uc *usecase) generateCardAudio(card *entity.Card, language string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	data, err := uc.ttsProvider.Synthesize(ctx, card.Word, language)
+	if err != nil {
+		return err
+	}
+	info, err := audio.Check(data)
+	if err != nil {
+		return err
+	}
+	audioUrl, err := uc.uploadCardAudio(data, card.Word, info.Format)
+	if err != nil {
+		return err
+	}
+	return uc.cardRepo.SetCardAudio(card.Id, audioUrl)
+}
+
+// checkAudio maps the audio validation errors to the usecase errors
+func checkAudio(data []byte) (*audio.Info, error) {
+	info, err := audio.Check(data)
+	if errors.Is(err, audio.ErrTooLong) {
+		return nil, ErrAudioTooLong
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAudio, err)
+	}
+	return info, nil
+}
+
+func (uc *usecase) uploadCardAudio(data []byte, name string, format audio.Format) (string, error) {
+	return media.UploadCardAudio(uc.gcsClient, uc.bucketName, uc.envPrefix, data, name, format)
+}
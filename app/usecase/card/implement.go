@@ -12,6 +12,7 @@ import (
 	"cloud.google.com/go/storage"
 	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
 	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/flash-cards-vocab/backend/pkg/tts"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -22,6 +23,7 @@ type usecase struct {
 	gcsClient      *storage.Client
 	bucketName     string
 	envPrefix      string
+	ttsProvider    tts.Provider
 }
 
 func New(
@@ -30,6 +32,7 @@ func New(
 	gcsClient *storage.Client,
 	bucketName string,
 	envPrefix string,
+	ttsProvider tts.Provider,
 ) UseCase {
 	return &usecase{
 		cardRepo:       cardRepo,
@@ -37,6 +40,7 @@ func New(
 		gcsClient:      gcsClient,
 		bucketName:     bucketName,
 		envPrefix:      envPrefix,
+		ttsProvider:    ttsProvider,
 	}
 }
 
@@ -65,11 +69,14 @@ func (u *usecase) UploadCardImage(
 	return fileURL, nil
 }
 
-func (uc *usecase) SearchByWord(word string, userId uuid.UUID, page, size int) (*entity.CardSearch, error) {
+func (uc *usecase) SearchByWord(word string, userId uuid.UUID, filter entity.SearchFilter, page, size int) (*entity.CardSearch, error) {
+	if err := searchFilterErrors.Of(filter.Normalize()); err != nil {
+		return nil, err
+	}
 	limit := size
 	offset := (page - 1) * size
 
-	userCards, err := uc.cardRepo.GetUserCardsByWord(word, userId, limit, offset)
+	userCards, err := uc.cardRepo.GetUserCardsByWord(word, userId, filter, limit, offset)
 	if err != nil {
 		if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
 			return nil, ErrNotFound
@@ -79,7 +86,7 @@ func (uc *usecase) SearchByWord(word string, userId uuid.UUID, page, size int) (
 	}
 	var globalCards []*entity.CardWithOccurence
 	if word != "" {
-		globalCards, err = uc.cardRepo.GetGlobalCardsByWord(word, userId, limit, offset)
+		globalCards, err = uc.cardRepo.GetGlobalCardsByWord(word, userId, filter, limit, offset)
 		if err != nil {
 			if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
 				return nil, ErrNotFound
@@ -157,3 +164,82 @@ func (uc *usecase) DontKnowCard(collectionId, cardId, userId uuid.UUID) (*entity
 	}
 	return collUserProgr, nil
 }
+
+// GetCardRevisions returns the previous contents of the card, the latest first
+func (uc *usecase) GetCardRevisions(id, userId uuid.UUID) ([]*entity.CardRevision, error) {
+	_, err := uc.getVisibleCard(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := uc.cardRepo.GetCardRevisions(id)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return revisions, nil
+}
+
+// getVisibleCard returns the card unless it is deleted or hidden from the user by moderation
+func (uc *usecase) getVisibleCard(id, userId uuid.UUID) (*entity.Card, error) {
+	card, err := uc.cardRepo.GetCardById(id)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCardNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if card.Hidden && card.AuthorId != userId {
+		return nil, ErrNotFound
+	}
+	return card, nil
+}
+
+// LikeCardById toggles the like of the user, liking a disliked card removes the dislike
+func (uc *usecase) LikeCardById(id, userId uuid.UUID) (*entity.CardFullUserMetricsResponse, error) {
+	_, err := uc.getVisibleCard(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.cardRepo.ToggleCardLike(id, userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return uc.getCardFullUserMetrics(id, userId)
+}
+
+// DislikeCardById toggles the dislike of the user, disliking a liked card removes the like
+func (uc *usecase) DislikeCardById(id, userId uuid.UUID) (*entity.CardFullUserMetricsResponse, error) {
+	_, err := uc.getVisibleCard(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.cardRepo.ToggleCardDislike(id, userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return uc.getCardFullUserMetrics(id, userId)
+}
+
+func (uc *usecase) getCardFullUserMetrics(id, userId uuid.UUID) (*entity.CardFullUserMetricsResponse, error) {
+	metrics, err := uc.cardRepo.GetCardMetrics(id)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	userMetrics, err := uc.cardRepo.GetCardUserMetrics(id, userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return &entity.CardFullUserMetricsResponse{
+		CardId:   id,
+		Likes:    metrics.Likes,
+		Dislikes: metrics.Dislikes,
+		UserId:   userId,
+		Liked:    userMetrics.Liked,
+		Disliked: userMetrics.Disliked,
+	}, nil
+}
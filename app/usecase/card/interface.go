@@ -2,6 +2,7 @@ package card_usecase
 
 import (
 	"errors"
+	"io"
 	"mime/multipart"
 
 	"github.com/flash-cards-vocab/backend/entity"
@@ -12,6 +13,24 @@ var ErrUnexpected = errors.New("Internal error")
 var ErrUnauthorized = errors.New("ErrUnauthorized")
 var ErrNotFound = errors.New("ErrNotFound")
 var ErrForbiddenSelfRequest = errors.New("Self request is forbidden")
+var ErrInvalidLanguage = errors.New("Language must be a valid BCP-47 tag")
+var ErrInvalidLevel = errors.New("Level must be at most 30 characters")
+var ErrInvalidAudio = errors.New("Audio must be an MP3, OGG or WAV file")
+var ErrAudioTooLong = errors.New("Audio must be at most 30 seconds and 5 MB")
+var ErrSpeechUnavailable = errors.New("Speech generation is not configured")
+var ErrInvalidNote = errors.New("Note must be at most 2000 characters and mnemonic at most 500")
+var ErrInvalidMnemonic = errors.New("Mnemonic must not be empty and at most 300 characters")
+var ErrMnemonicAlreadyPublished = errors.New("Mnemonic for this card is already published by this user")
+var ErrMnemonicLimitReached = errors.New("At most 20 mnemonics can be published in 24 hours")
+var ErrInvalidDepth = errors.New("Depth must be 1 or 2")
+var ErrInvalidPartOfSpeech = errors.New("Part of speech must be one of noun, verb, adjective, adverb, pronoun, preposition, conjunction, interjection, determiner, numeral, particle, phrase")
+
+// searchFilterErrors maps the errors of entity.SearchFilter.Normalize to the usecase errors
+var searchFilterErrors = entity.SearchFilterErrors{
+	Language:     ErrInvalidLanguage,
+	Level:        ErrInvalidLevel,
+	PartOfSpeech: ErrInvalidPartOfSpeech,
+}
 
 type UseCase interface {
 	// UploadCardImage(file multipart.File, location string, filename string) (string, error)
@@ -21,7 +40,20 @@ type UseCase interface {
 		filename string,
 	) (string, error)
 	AddExistingCardToCollection(collectionId uuid.UUID, cardId uuid.UUID) error
-	SearchByWord(word string, userId uuid.UUID, page, size int) (*entity.CardSearch, error)
+	SearchByWord(word string, userId uuid.UUID, filter entity.SearchFilter, page, size int) (*entity.CardSearch, error)
 	KnowCard(collectionId, cardId, userId uuid.UUID) (*entity.CollectionUserProgress, error)
 	DontKnowCard(collectionId, cardId, userId uuid.UUID) (*entity.CollectionUserProgress, error)
+	GetCardRevisions(id, userId uuid.UUID) ([]*entity.CardRevision, error)
+	GetRelatedCards(id, userId uuid.UUID, depth int) (*entity.CardRelatedGraph, error)
+	SaveCardNote(cardId, userId uuid.UUID, request entity.SaveCardNoteRequest) (*entity.CardNote, error)
+	DeleteCardNote(cardId, userId uuid.UUID) error
+	PublishCardMnemonic(cardId, userId uuid.UUID, request entity.PublishCardMnemonicRequest) (*entity.CardMnemonic, error)
+	GetCardMnemonics(cardId, userId uuid.UUID, page, size int) (*entity.CardMnemonicPagination, error)
+	UpvoteCardMnemonic(id, userId uuid.UUID) (*entity.CardMnemonic, error)
+	DownvoteCardMnemonic(id, userId uuid.UUID) (*entity.CardMnemonic, error)
+	DeleteCardMnemonic(id, userId uuid.UUID) error
+	LikeCardById(id, userId uuid.UUID) (*entity.CardFullUserMetricsResponse, error)
+	DislikeCardById(id, userId uuid.UUID) (*entity.CardFullUserMetricsResponse, error)
+	UploadCardAudio(file io.Reader, filename string) (string, error)
+	GenerateMissingAudio(collectionId, userId uuid.UUID) (*entity.CardAudioGeneration, error)
 }
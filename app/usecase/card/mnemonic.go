@@ -0,0 +1,149 @@
+package card_usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	publicMnemonicMaxLen = 300
+	// A user can publish at most userMnemonicsPerDay mnemonics in any 24 hours
+	userMnemonicsPerDay = 20
+)
+
+// PublishCardMnemonic shares a mnemonic of the user with everyone studying the card, a user has
+// one mnemonic per card
+func (uc *usecase) PublishCardMnemonic(cardId, userId uuid.UUID, request entity.PublishCardMnemonicRequest) (*entity.CardMnemonic, error) {
+	text := strings.TrimSpace(request.Text)
+	if text == "" || utf8.RuneCountInString(text) > publicMnemonicMaxLen {
+		return nil, ErrInvalidMnemonic
+	}
+	card, err := uc.cardRepo.GetCardById(cardId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCardNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	published, err := uc.cardRepo.HasUserCardMnemonic(card.Id, userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if published {
+		return nil, ErrMnemonicAlreadyPublished
+	}
+	count, err := uc.cardRepo.CountUserMnemonicsSince(userId, time.Now().Add(-time.Hour*24))
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if count >= userMnemonicsPerDay {
+		return nil, ErrMnemonicLimitReached
+	}
+
+	mnemonic, err := uc.cardRepo.CreateCardMnemonic(entity.CardMnemonic{
+		CardId:   card.Id,
+		AuthorId: userId,
+		Text:     text,
+	})
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCardMnemonicExists) {
+			return nil, ErrMnemonicAlreadyPublished
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return mnemonic, nil
+}
+
+func (uc *usecase) GetCardMnemonics(cardId, userId uuid.UUID, page, size int) (*entity.CardMnemonicPagination, error) {
+	_, err := uc.cardRepo.GetCardById(cardId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCardNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	limit := size
+	offset := (page - 1) * size
+	mnemonics, total, err := uc.cardRepo.GetCardMnemonics(cardId, userId, limit, offset)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return &entity.CardMnemonicPagination{
+		Mnemonics: mnemonics,
+		Page:      page,
+		Size:      size,
+		Total:     total,
+	}, nil
+}
+
+// UpvoteCardMnemonic toggles the upvote of the user, upvoting a downvoted mnemonic removes the downvote
+func (uc *usecase) UpvoteCardMnemonic(id, userId uuid.UUID) (*entity.CardMnemonic, error) {
+	return uc.voteCardMnemonic(id, userId, 1)
+}
+
+// DownvoteCardMnemonic toggles the downvote of the user, downvoting an upvoted mnemonic removes the upvote
+func (uc *usecase) DownvoteCardMnemonic(id, userId uuid.UUID) (*entity.CardMnemonic, error) {
+	return uc.voteCardMnemonic(id, userId, -1)
+}
+
+func (uc *usecase) voteCardMnemonic(id, userId uuid.UUID, vote int) (*entity.CardMnemonic, error) {
+	mnemonic, err := uc.getCardMnemonic(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	if mnemonic.AuthorId == userId {
+		return nil, ErrForbiddenSelfRequest
+	}
+	if mnemonic.Vote == vote {
+		vote = 0
+	}
+	err = uc.cardRepo.VoteCardMnemonic(id, userId, vote)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return uc.getCardMnemonic(id, userId)
+}
+
+// DeleteCardMnemonic removes a mnemonic published by the user
+func (uc *usecase) DeleteCardMnemonic(id, userId uuid.UUID) error {
+	mnemonic, err := uc.getCardMnemonic(id, userId)
+	if err != nil {
+		return err
+	}
+	if mnemonic.AuthorId != userId {
+		return ErrUnauthorized
+	}
+	err = uc.cardRepo.DeleteCardMnemonic(id)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+func (uc *usecase) getCardMnemonic(id, userId uuid.UUID) (*entity.CardMnemonic, error) {
+	mnemonic, err := uc.cardRepo.GetCardMnemonic(id, userId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCardMnemonicNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return mnemonic, nil
+}
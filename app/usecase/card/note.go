@@ -0,0 +1,65 @@
+package card_usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	cardNoteMaxLen        = 2000
+	privateMnemonicMaxLen = 500
+)
+
+// SaveCardNote sets the private note and mnemonic of the user on the card, the user does not need to be
+// the author of the card. Saving an empty note and mnemonic removes them.
+func (uc *usecase) SaveCardNote(cardId, userId uuid.UUID, request entity.SaveCardNoteRequest) (*entity.CardNote, error) {
+	note := strings.TrimSpace(request.Note)
+	mnemonic := strings.TrimSpace(request.Mnemonic)
+	if utf8.RuneCountInString(note) > cardNoteMaxLen || utf8.RuneCountInString(mnemonic) > privateMnemonicMaxLen {
+		return nil, ErrInvalidNote
+	}
+	_, err := uc.cardRepo.GetCardById(cardId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCardNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+
+	if note == "" && mnemonic == "" {
+		err = uc.cardRepo.DeleteCardNote(cardId, userId)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		return &entity.CardNote{CardId: cardId, UserId: userId}, nil
+	}
+	saved, err := uc.cardRepo.SaveCardNote(entity.CardNote{
+		CardId:   cardId,
+		UserId:   userId,
+		Note:     note,
+		Mnemonic: mnemonic,
+	})
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return saved, nil
+}
+
+func (uc *usecase) DeleteCardNote(cardId, userId uuid.UUID) error {
+	err := uc.cardRepo.DeleteCardNote(cardId, userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package card_usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	relatedMaxDepth = 2
+	// relatedMaxNodes bounds the graph of words shared by many cards
+	relatedMaxNodes = 200
+)
+
+// GetRelatedCards returns the graph of the synonyms and antonyms of the card. The graph follows the links
+// of the related words to their cards and the cards linking back, up to depth relations away from the card.
+func (uc *usecase) GetRelatedCards(id, userId uuid.UUID, depth int) (*entity.CardRelatedGraph, error) {
+	if depth < 1 || depth > relatedMaxDepth {
+		return nil, ErrInvalidDepth
+	}
+	card, err := uc.getVisibleCard(id, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := newRelatedGraph(id)
+	graph.addCard(card, 0)
+	frontier := []*entity.Card{card}
+	for level := 1; level <= depth && len(frontier) > 0 && len(graph.Nodes) < relatedMaxNodes; level++ {
+		linkedIds := []uuid.UUID{}
+		for _, card := range frontier {
+			for _, word := range relatedWords(card) {
+				if word.CardId != nil && graph.nodes[word.CardId.String()] == nil {
+					linkedIds = append(linkedIds, *word.CardId)
+				}
+			}
+		}
+		linked, err := uc.cardRepo.GetCardsByIds(linkedIds)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		next := []*entity.Card{}
+		for _, card := range linked {
+			if graph.addCard(card, level) {
+				next = append(next, card)
+			}
+		}
+		for _, card := range frontier {
+			for _, word := range card.SynonymList {
+				graph.addWord(card, word, entity.CardRelation_Synonym, level)
+			}
+			for _, word := range card.AntonymList {
+				graph.addWord(card, word, entity.CardRelation_Antonym, level)
+			}
+		}
+
+		frontierIds := []uuid.UUID{}
+		for _, card := range frontier {
+			frontierIds = append(frontierIds, card.Id)
+		}
+		linking, err := uc.cardRepo.GetCardsLinkingTo(frontierIds)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		for _, card := range linking {
+			if graph.addCard(card, level) {
+				next = append(next, card)
+			}
+			// Only the links back to the graph, the other words of the card are added on the next level
+			for _, word := range card.SynonymList {
+				graph.addLink(card, word, entity.CardRelation_Synonym)
+			}
+			for _, word := range card.AntonymList {
+				graph.addLink(card, word, entity.CardRelation_Antonym)
+			}
+		}
+		frontier = next
+	}
+	return graph.CardRelatedGraph, nil
+}
+
+func relatedWords(card *entity.Card) []*entity.RelatedWord {
+	return append(append([]*entity.RelatedWord{}, card.SynonymList...), card.AntonymList...)
+}
+
+// relatedGraph indexes the nodes and edges of the graph being built by their keys
+type relatedGraph struct {
+	*entity.CardRelatedGraph
+	nodes map[string]*entity.CardRelatedNode
+	edges map[entity.CardRelatedEdge]bool
+}
+
+func newRelatedGraph(cardId uuid.UUID) *relatedGraph {
+	return &relatedGraph{
+		CardRelatedGraph: &entity.CardRelatedGraph{
+			CardId: cardId,
+			Nodes:  []*entity.CardRelatedNode{},
+			Edges:  []*entity.CardRelatedEdge{},
+		},
+		nodes: map[string]*entity.CardRelatedNode{},
+		edges: map[entity.CardRelatedEdge]bool{},
+	}
+}
+
+// addCard adds the card node and tells whether it was not in the graph yet
+func (g *relatedGraph) addCard(card *entity.Card, depth int) bool {
+	key := card.Id.String()
+	if g.nodes[key] != nil || len(g.Nodes) >= relatedMaxNodes {
+		return false
+	}
+	cardId := card.Id
+	node := &entity.CardRelatedNode{
+		Key:        key,
+		CardId:     &cardId,
+		Word:       card.Word,
+		Definition: card.Definition,
+		ImageUrl:   card.ImageUrl,
+		Depth:      depth,
+	}
+	g.nodes[key] = node
+	g.Nodes = append(g.Nodes, node)
+	return true
+}
+
+// addWord adds the edge from the card to its related word, a word linked to a card that is not
+// in the graph, as it is deleted or hidden, is added as a word without a card
+func (g *relatedGraph) addWord(card *entity.Card, word *entity.RelatedWord, relation entity.CardRelation, depth int) {
+	key := ""
+	if word.CardId != nil && g.nodes[word.CardId.String()] != nil {
+		key = word.CardId.String()
+	} else {
+		key = "word:" + strings.ToLower(word.Word)
+		if g.nodes[key] == nil {
+			if len(g.Nodes) >= relatedMaxNodes {
+				return
+			}
+			node := &entity.CardRelatedNode{Key: key, Word: word.Word, Depth: depth}
+			g.nodes[key] = node
+			g.Nodes = append(g.Nodes, node)
+		}
+	}
+	g.addEdge(card.Id.String(), key, relation)
+}
+
+// addLink adds the edge from the card to its related word when the word is linked to a card of the graph
+func (g *relatedGraph) addLink(card *entity.Card, word *entity.RelatedWord, relation entity.CardRelation) {
+	if word.CardId != nil && g.nodes[word.CardId.String()] != nil {
+		g.addEdge(card.Id.String(), word.CardId.String(), relation)
+	}
+}
+
+func (g *relatedGraph) addEdge(from, to string, relation entity.CardRelation) {
+	edge := entity.CardRelatedEdge{From: from, To: to, Relation: relation}
+	if from == to || g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.Edges = append(g.Edges, &edge)
+}
@@ -0,0 +1,224 @@
+package collection_usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/flash-cards-vocab/backend/pkg/anki"
+	"github.com/flash-cards-vocab/backend/pkg/audio"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Anki note field names recognized for every card field, compared case-insensitively.
+// Notes without matching field names use the first field as the word and the second one as the definition.
+var ankiWordFields = []string{"word", "front", "term", "expression", "vocabulary", "vocab"}
+var ankiDefinitionFields = []string{"definition", "back", "meaning", "translation", "answer"}
+var ankiSentenceFields = []string{"sentence", "example", "examples", "example sentence", "context"}
+var ankiImageFields = []string{"image", "picture", "img"}
+var ankiAudioFields = []string{"audio", "sound"}
+
+// AnkiPackageMaxSize is the largest .apkg file that can be imported
+const AnkiPackageMaxSize = anki.MaxPackageSize
+
+func (uc *usecase) ImportAnkiCollection(
+	userId uuid.UUID,
+	collection entity.Collection,
+	file io.ReaderAt,
+	size int64,
+	duplicates entity.DuplicatePolicy,
+) (*entity.ImportReport, error) {
+	duplicates, err := duplicatePolicy(duplicates)
+	if err != nil {
+		return nil, err
+	}
+	if size > AnkiPackageMaxSize {
+		return nil, fmt.Errorf("%w: package is larger than %d bytes", ErrInvalidAnkiPackage, AnkiPackageMaxSize)
+	}
+	pkg, err := anki.Read(file, size)
+	if err != nil {
+		if errors.Is(err, anki.ErrInvalidPackage) || errors.Is(err, anki.ErrUnsupportedPackage) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAnkiPackage, err)
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+
+	report := &entity.ImportReport{
+		Name:       collection.Name,
+		Skipped:    []*entity.ImportSkip{},
+		Duplicates: []*entity.ImportDuplicate{},
+		Warnings:   []*entity.ImportSkip{},
+	}
+	cards := []*entity.Card{}
+	// Media shared by several notes is uploaded once
+	uploadedImages := map[string]string{}
+	uploadedAudio := map[string]string{}
+	for _, note := range pkg.Notes {
+		source := fmt.Sprintf("note %d", note.Id)
+		card, imageFilename, audioFilename := ankiNoteToCard(note)
+		if card.Word == "" {
+			report.Skipped = append(report.Skipped, &entity.ImportSkip{Source: source, Reason: "word is empty"})
+			continue
+		}
+		if card.Definition == "" {
+			report.Skipped = append(report.Skipped, &entity.ImportSkip{Source: source, Reason: "definition is empty"})
+			continue
+		}
+		if imageFilename != "" {
+			imageUrl, ok := uploadedImages[imageFilename]
+			if !ok {
+				// The image is optional as well, a card is imported without an image that is missing or cannot be downloaded
+				if strings.HasPrefix(imageFilename, "http://") || strings.HasPrefix(imageFilename, "https://") {
+					imageUrl, err = uc.storeImageFromUrl(imageFilename, card.Word)
+				} else {
+					imageUrl, err = uc.uploadAnkiMedia(pkg, imageFilename)
+				}
+				if errors.Is(err, anki.ErrMediaNotFound) {
+					report.Warnings = append(report.Warnings, &entity.ImportSkip{Source: source, Reason: "image " + imageFilename + " is missing in the package, the card is created without it"})
+				} else if errors.Is(err, anki.ErrMediaTooLarge) {
+					report.Warnings = append(report.Warnings, &entity.ImportSkip{Source: source, Reason: "image " + imageFilename + " is too large, the card is created without it"})
+				} else if err != nil {
+					logrus.Warnf("failed to import image %s of note %d: %v", imageFilename, note.Id, err)
+					report.Warnings = append(report.Warnings, &entity.ImportSkip{Source: source, Reason: "image " + imageFilename + " could not be stored, the card is created without it"})
+				}
+				uploadedImages[imageFilename] = imageUrl
+			}
+			card.ImageUrl = imageUrl
+		}
+		if audioFilename != "" {
+			audioUrl, ok := uploadedAudio[audioFilename]
+			if !ok {
+				// Audio is optional, a card keeps its other fields when its sound is missing or invalid
+				audioUrl, err = uc.uploadAnkiAudio(pkg, audioFilename)
+				if err != nil {
+					logrus.Warnf("failed to import sound %s of note %d: %v", audioFilename, note.Id, err)
+				}
+				uploadedAudio[audioFilename] = audioUrl
+			}
+			card.AudioUrl = audioUrl
+		}
+		card.AuthorId = userId
+		cards = append(cards, card)
+	}
+	if len(cards) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	collection.AuthorId = userId
+	err = setCollectionLanguages(&collection, cards)
+	if err != nil {
+		return nil, err
+	}
+	err = setCollectionLevels(&collection, cards)
+	if err != nil {
+		return nil, err
+	}
+	err = normalizeCardBodies(cards)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := uc.resolveDuplicates(userId, cards, duplicates)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	created, err := uc.collectionRepo.CreateCollectionWithCards(collection, resolved.Cards, resolved.ExistingCardIds)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	report.CollectionId = created.Id
+	report.Name = created.Name
+	report.CardsAmount = resolved.amount()
+	report.Duplicates = resolved.Duplicates
+	return report, nil
+}
+
+func (uc *usecase) uploadAnkiMedia(pkg *anki.Package, filename string) (string, error) {
+	media, err := pkg.OpenMedia(filename)
+	if err != nil {
+		return "", err
+	}
+	defer media.Close()
+	return uc.uploadCardImage(media, path.Base(filename))
+}
+
+func (uc *usecase) uploadAnkiAudio(pkg *anki.Package, filename string) (string, error) {
+	media, err := pkg.OpenMedia(filename)
+	if err != nil {
+		return "", err
+	}
+	defer media.Close()
+	data, err := io.ReadAll(io.LimitReader(media, audio.MaxSize+1))
+	if err != nil {
+		return "", err
+	}
+	return uc.uploadCardAudio(data, strings.TrimSuffix(path.Base(filename), path.Ext(filename)))
+}
+
+// ankiNoteToCard maps the note fields onto a card and returns the filenames of the card image and sound if the note has them
+func ankiNoteToCard(note *anki.Note) (*entity.Card, string, string) {
+	fields := map[string]string{}
+	for _, name := range note.FieldNames {
+		fields[strings.ToLower(strings.TrimSpace(name))] = note.Fields[name]
+	}
+	lookup := func(names []string) (string, bool) {
+		for _, name := range names {
+			if value, ok := fields[name]; ok {
+				return value, true
+			}
+		}
+		return "", false
+	}
+
+	word, ok := lookup(ankiWordFields)
+	if !ok && len(note.FieldNames) > 0 {
+		word = note.Fields[note.FieldNames[0]]
+	}
+	definition, ok := lookup(ankiDefinitionFields)
+	if !ok && len(note.FieldNames) > 1 {
+		definition = note.Fields[note.FieldNames[1]]
+	}
+	sentence, _ := lookup(ankiSentenceFields)
+
+	imageFilename := ""
+	if image, ok := lookup(ankiImageFields); ok {
+		if sources := anki.ImageSources(image); len(sources) > 0 {
+			imageFilename = sources[0]
+		}
+	}
+	if imageFilename == "" {
+		for _, name := range note.FieldNames {
+			if sources := anki.ImageSources(note.Fields[name]); len(sources) > 0 {
+				imageFilename = sources[0]
+				break
+			}
+		}
+	}
+
+	audioFilename := ""
+	if sound, ok := lookup(ankiAudioFields); ok {
+		if sources := anki.SoundSources(sound); len(sources) > 0 {
+			audioFilename = sources[0]
+		}
+	}
+	if audioFilename == "" {
+		for _, name := range note.FieldNames {
+			if sources := anki.SoundSources(note.Fields[name]); len(sources) > 0 {
+				audioFilename = sources[0]
+				break
+			}
+		}
+	}
+
+	return &entity.Card{
+		Word:       anki.PlainText(word),
+		Definition: anki.PlainText(definition),
+		Sentence:   anki.PlainText(sentence),
+	}, imageFilename, audioFilename
+}
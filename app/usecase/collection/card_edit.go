@@ -0,0 +1,131 @@
+package collection_usecase
+
+import (
+	"errors"
+	"fmt"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// editCollectionCard edits a card of the collection in place and keeps its previous content as a revision.
+// A card of another author is replaced in the collection by an edited copy instead,
+// so is a card that is also in collections of other authors when fork is set.
+// Only the author of the collection edits its cards.
+func (uc *usecase) editCollectionCard(userId, collectionId uuid.UUID, card *entity.Card, fork bool) error {
+	collection, err := uc.getCollection(collectionId)
+	if err != nil {
+		return err
+	}
+	if collection.AuthorId != userId {
+		return ErrUnauthorized
+	}
+	inCollection, err := uc.cardRepo.IsCardInCollection(collectionId, card.Id)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if !inCollection {
+		return ErrNotFound
+	}
+	existing, err := uc.cardRepo.GetCardById(card.Id)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCardNotFound) {
+			return ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	keepCardBody(existing, card)
+	if sameCardContent(existing, card) {
+		return nil
+	}
+
+	if existing.AuthorId == userId && fork {
+		fork, err = uc.cardRepo.IsCardSharedWithOtherAuthors(card.Id, userId)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+	}
+	if existing.AuthorId != userId || fork {
+		card.AuthorId = userId
+		_, err = uc.cardRepo.ForkCard(collectionId, card.Id, *card)
+	} else {
+		err = uc.cardRepo.UpdateCard(*card, userId)
+	}
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCardNotFound) {
+			return ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+func sameCardContent(existing, card *entity.Card) bool {
+	return existing.Word == card.Word &&
+		existing.ImageUrl == card.ImageUrl &&
+		existing.AudioUrl == card.AudioUrl &&
+		existing.Definition == card.Definition &&
+		existing.Sentence == card.Sentence &&
+		existing.Antonyms == card.Antonyms &&
+		existing.Synonyms == card.Synonyms &&
+		entity.SameRelatedWords(existing.SynonymList, card.SynonymList) &&
+		entity.SameRelatedWords(existing.AntonymList, card.AntonymList) &&
+		entity.SameSenses(existing.Senses, card.Senses) &&
+		existing.Pronunciation == card.Pronunciation &&
+		existing.Gender == card.Gender &&
+		existing.Plural == card.Plural &&
+		existing.SourceLanguage == card.SourceLanguage &&
+		existing.TargetLanguage == card.TargetLanguage &&
+		existing.Level == card.Level
+}
+
+// keepCardBody fills in the body of an edit sent without senses or related word lists, as old clients do,
+// from the existing card.
+// The senses are kept while the definition and sentence they summarize are unchanged, otherwise the edited
+// definition replaces them keeping the part of speech of the first sense.
+// Such an edit can not clear the audio, pronunciation, gender or plural.
+func keepCardBody(existing, card *entity.Card) {
+	card.SynonymList = keepRelatedWords(existing.Synonyms, existing.SynonymList, card.Synonyms, card.SynonymList)
+	card.AntonymList = keepRelatedWords(existing.Antonyms, existing.AntonymList, card.Antonyms, card.AntonymList)
+	if card.Senses != nil {
+		return
+	}
+	if card.AudioUrl == "" {
+		card.AudioUrl = existing.AudioUrl
+	}
+	if card.Pronunciation == "" {
+		card.Pronunciation = existing.Pronunciation
+	}
+	if card.Gender == "" {
+		card.Gender = existing.Gender
+	}
+	if card.Plural == "" {
+		card.Plural = existing.Plural
+	}
+	if len(existing.Senses) == 0 {
+		return
+	}
+	if card.Definition == existing.Definition && card.Sentence == existing.Sentence {
+		card.Senses = existing.Senses
+		return
+	}
+	card.Senses = entity.NewCardSenses(string(firstPartOfSpeech(existing.Senses)), card.Definition, card.Sentence)
+}
+
+// keepRelatedWords reads the related words of an edit sent with the flat text only, the words that
+// were already related keep their card links
+func keepRelatedWords(existingText string, existing []*entity.RelatedWord, text string, words []*entity.RelatedWord) []*entity.RelatedWord {
+	if words != nil {
+		return words
+	}
+	if text == existingText {
+		return existing
+	}
+	return entity.ParseRelatedWords(text, existing)
+}
@@ -0,0 +1,33 @@
+package collection_usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flash-cards-vocab/backend/entity"
+)
+
+// normalizeCardBodies validates the senses, pronunciation, gender and plural of the cards
+// and sets the flat definition and sentence of the cards with senses to their summary
+func normalizeCardBodies(cards []*entity.Card) error {
+	for _, card := range cards {
+		err := entity.NormalizeCardBody(card)
+		if errors.Is(err, entity.ErrInvalidPartOfSpeech) {
+			return fmt.Errorf("%w: %s", ErrInvalidPartOfSpeech, card.Word)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidCardBody, card.Word)
+		}
+	}
+	return nil
+}
+
+// firstPartOfSpeech is the part of speech shown in the single column of tables and templates
+func firstPartOfSpeech(senses []*entity.CardSense) entity.PartOfSpeech {
+	for _, sense := range senses {
+		if sense.PartOfSpeech != "" {
+			return sense.PartOfSpeech
+		}
+	}
+	return ""
+}
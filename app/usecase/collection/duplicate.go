@@ -0,0 +1,126 @@
+package collection_usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// resolvedCards are the imported cards once the duplicate policy is applied
+type resolvedCards struct {
+	// Cards are the cards to create
+	Cards []*entity.Card
+	// ExistingCardIds are the cards of the user added to the collection instead of new cards
+	ExistingCardIds []uuid.UUID
+	Duplicates      []*entity.ImportDuplicate
+}
+
+func (r *resolvedCards) amount() uint32 {
+	return uint32(len(r.Cards) + len(r.ExistingCardIds))
+}
+
+// duplicatePolicy returns the policy to apply, keep when it is not set
+func duplicatePolicy(policy entity.DuplicatePolicy) (entity.DuplicatePolicy, error) {
+	if policy == "" {
+		return entity.DuplicatePolicy_Keep, nil
+	}
+	if !policy.IsValid() {
+		return "", ErrInvalidDuplicatePolicy
+	}
+	return policy, nil
+}
+
+// duplicateKey is the normalized word and definition two cards are compared by
+func duplicateKey(word, definition string) string {
+	return normalizeDuplicateText(word) + "\x00" + normalizeDuplicateText(definition)
+}
+
+func normalizeDuplicateText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// resolveDuplicates finds the cards duplicating an earlier card of the import or a card the user
+// already authored and applies the policy to them, the other cards are kept in their order
+func (uc *usecase) resolveDuplicates(userId uuid.UUID, cards []*entity.Card, policy entity.DuplicatePolicy) (*resolvedCards, error) {
+	resolved := &resolvedCards{
+		Cards:           []*entity.Card{},
+		ExistingCardIds: []uuid.UUID{},
+		Duplicates:      []*entity.ImportDuplicate{},
+	}
+
+	words := []string{}
+	wordAdded := map[string]bool{}
+	for _, card := range cards {
+		word := strings.ToLower(strings.TrimSpace(card.Word))
+		if !wordAdded[word] {
+			wordAdded[word] = true
+			words = append(words, word)
+		}
+	}
+	existing, err := uc.cardRepo.GetAuthorCardsByWords(userId, words)
+	if err != nil {
+		return nil, err
+	}
+	// The oldest card is reused when the user already has the same card several times
+	existingCards := map[string]*entity.Card{}
+	for _, card := range existing {
+		key := duplicateKey(card.Word, card.Definition)
+		if _, ok := existingCards[key]; !ok {
+			existingCards[key] = card
+		}
+	}
+
+	seen := map[string]bool{}
+	linked := map[uuid.UUID]bool{}
+	for _, card := range cards {
+		key := duplicateKey(card.Word, card.Definition)
+		existingCard, isExisting := existingCards[key]
+		if !isExisting && !seen[key] {
+			seen[key] = true
+			resolved.Cards = append(resolved.Cards, card)
+			continue
+		}
+
+		duplicate := &entity.ImportDuplicate{Word: card.Word, Definition: card.Definition}
+		if isExisting {
+			duplicate.CardId = &existingCard.Id
+		}
+		switch policy {
+		case entity.DuplicatePolicy_Skip:
+			duplicate.Action = entity.DuplicateAction_Skipped
+		case entity.DuplicatePolicy_Merge:
+			duplicate.Action = entity.DuplicateAction_Merged
+			if isExisting && !linked[existingCard.Id] {
+				linked[existingCard.Id] = true
+				resolved.ExistingCardIds = append(resolved.ExistingCardIds, existingCard.Id)
+			}
+		default:
+			duplicate.Action = entity.DuplicateAction_Kept
+			resolved.Cards = append(resolved.Cards, card)
+		}
+		resolved.Duplicates = append(resolved.Duplicates, duplicate)
+	}
+	return resolved, nil
+}
+
+// collectionCardIds returns the ids of the cards in the collection
+func (uc *usecase) collectionCardIds(collectionId, userId uuid.UUID) (map[uuid.UUID]bool, error) {
+	total, err := uc.collectionRepo.GetTotalCardsInCollection(collectionId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	cards, err := uc.collectionRepo.GetCollectionCards(collectionId, userId, total, 0)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	ids := map[uuid.UUID]bool{}
+	for _, card := range cards.CardForUser {
+		ids[card.Id] = true
+	}
+	return ids, nil
+}
@@ -0,0 +1,190 @@
+package collection_usecase
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/flash-cards-vocab/backend/pkg/anki"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var exportFilenameRegexp = regexp.MustCompile(`[^\p{L}\p{N}_-]+`)
+
+var ankiNoteType = anki.NoteType{
+	Name:   "Flash Cards Vocab",
+	Fields: []string{"Word", "Definition", "Sentence", "Image", "Synonyms", "Antonyms", "Audio"},
+	QuestionFormat: `<div class="word">{{Word}}</div>
+{{Audio}}
+<div class="image">{{Image}}</div>`,
+	AnswerFormat: `{{FrontSide}}
+<hr id="answer">
+<div class="definition">{{Definition}}</div>
+{{#Sentence}}<div class="sentence">{{Sentence}}</div>{{/Sentence}}
+{{#Synonyms}}<div class="related">Synonyms: {{Synonyms}}</div>{{/Synonyms}}
+{{#Antonyms}}<div class="related">Antonyms: {{Antonyms}}</div>{{/Antonyms}}`,
+	Css: `.card { font-family: arial; font-size: 20px; text-align: center; color: black; background-color: white; }
+.word { font-size: 32px; font-weight: bold; }
+.image img { max-width: 100%; max-height: 300px; }
+.sentence { font-style: italic; margin-top: 12px; }
+.related { font-size: 16px; color: #555; margin-top: 8px; }`,
+}
+
+// Anki schedule of the cards by the progress of the user, cards without progress are exported as new
+var ankiSchedules = map[entity.CardUserProgressType]*anki.Schedule{
+	entity.CardUserProgressType_Learning:  {Interval: 1, Due: 0},
+	entity.CardUserProgressType_Reviewing: {Interval: 3, Due: 3},
+	entity.CardUserProgressType_Mastered:  {Interval: 21, Due: 21},
+}
+
+func (uc *usecase) ExportCollection(id, userId uuid.UUID, options entity.ExportOptions) (*entity.ExportFile, error) {
+	format := options.Format
+	if !format.IsValid() {
+		return nil, ErrInvalidExportFormat
+	}
+	if format == entity.ExportFormat_Pdf && !validPdfOptions(options) {
+		return nil, ErrInvalidPdfOptions
+	}
+	collection, err := uc.getCollection(id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.Hidden && collection.AuthorId != userId {
+		return nil, ErrNotFound
+	}
+
+	total, err := uc.collectionRepo.GetTotalCardsInCollection(id)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	cards, err := uc.collectionRepo.GetCollectionCards(id, userId, total, 0)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+
+	progress := map[uuid.UUID]entity.CardUserProgressType{}
+	if options.IncludeProgress && format == entity.ExportFormat_Apkg {
+		cardsProgress, err := uc.collectionRepo.GetCollectionCardsProgress(id, userId)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		for _, cardProgress := range cardsProgress {
+			progress[cardProgress.CardId] = cardProgress.Status
+		}
+	}
+
+	var data []byte
+	contentType := "application/octet-stream"
+	switch format {
+	case entity.ExportFormat_Apkg:
+		data, err = uc.exportApkg(collection, cards.CardForUser, progress)
+	case entity.ExportFormat_Xlsx:
+		// The spreadsheet formats use the upload template so the file can be edited and uploaded back
+		data, err = exportXlsx(collectionTemplateRows(collection, cards.CardForUser))
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case entity.ExportFormat_Csv:
+		data, err = exportCsv(collectionTemplateRows(collection, cards.CardForUser))
+		contentType = "text/csv"
+	case entity.ExportFormat_Pdf:
+		data, err = uc.exportPdf(collection, cards.CardForUser, options)
+		contentType = "application/pdf"
+	}
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return &entity.ExportFile{
+		Filename:    exportFilename(collection.Name, string(format)),
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
+func (uc *usecase) exportApkg(
+	collection *entity.Collection,
+	cards []*entity.CardForUser,
+	progress map[uuid.UUID]entity.CardUserProgressType,
+) ([]byte, error) {
+	deck := &anki.Deck{
+		Name:     collection.Name,
+		NoteType: ankiNoteType,
+		Notes:    []*anki.ExportNote{},
+		Media:    map[string][]byte{},
+	}
+	tags := []string{}
+	for _, topic := range collection.Topics {
+		if topic = strings.Join(strings.Fields(topic), "_"); topic != "" {
+			tags = append(tags, topic)
+		}
+	}
+	for _, card := range cards {
+		image := ""
+		if card.ImageUrl != "" {
+			// A card is still exported when its image is not available
+			data, filename, err := uc.downloadCardMedia(card.ImageUrl)
+			if err != nil {
+				logrus.Warnf("failed to download image of card %s: %v", card.Id, err)
+			} else {
+				if _, ok := deck.Media[filename]; ok {
+					filename = card.Id.String() + "-" + filename
+				}
+				deck.Media[filename] = data
+				image = fmt.Sprintf(`<img src="%s">`, html.EscapeString(filename))
+			}
+		}
+		sound := ""
+		if card.AudioUrl != "" {
+			data, filename, err := uc.downloadCardMedia(card.AudioUrl)
+			if err != nil {
+				logrus.Warnf("failed to download audio of card %s: %v", card.Id, err)
+			} else {
+				if _, ok := deck.Media[filename]; ok {
+					filename = card.Id.String() + "-" + filename
+				}
+				deck.Media[filename] = data
+				sound = "[sound:" + filename + "]"
+			}
+		}
+		deck.Notes = append(deck.Notes, &anki.ExportNote{
+			Guid: card.Id.String(),
+			Fields: []string{
+				ankiFieldValue(card.Word),
+				ankiFieldValue(card.Definition),
+				ankiFieldValue(card.Sentence),
+				image,
+				ankiFieldValue(card.Synonyms),
+				ankiFieldValue(card.Antonyms),
+				sound,
+			},
+			Tags:     tags,
+			Schedule: ankiSchedules[progress[card.Id]],
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	err := anki.Write(buf, deck)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func ankiFieldValue(value string) string {
+	return strings.ReplaceAll(html.EscapeString(value), "\n", "<br>")
+}
+
+// exportFilename builds a filename safe for the Content-Disposition header from the collection name
+func exportFilename(name, extension string) string {
+	name = strings.Trim(exportFilenameRegexp.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		name = "collection"
+	}
+	return name + "." + extension
+}
@@ -1,6 +1,7 @@
 package collection_usecase
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -15,7 +16,6 @@ import (
 	"github.com/flash-cards-vocab/backend/entity"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
-	"github.com/xuri/excelize/v2"
 	"gorm.io/gorm"
 )
 
@@ -23,15 +23,19 @@ type usecase struct {
 	collectionRepo repositoryIntf.CollectionRepository
 	cardRepo       repositoryIntf.CardRepository
 	userRepo       repositoryIntf.UserRepository
+	importJobRepo  repositoryIntf.ImportJobRepository
 	gcsClient      *storage.Client
 	bucketName     string
 	envPrefix      string
+	// importJobSlots limits how many import jobs run at the same time
+	importJobSlots chan struct{}
 }
 
 func New(
 	collectionRepo repositoryIntf.CollectionRepository,
 	cardRepo repositoryIntf.CardRepository,
 	userRepo repositoryIntf.UserRepository,
+	importJobRepo repositoryIntf.ImportJobRepository,
 	gcsClient *storage.Client,
 	bucketName string,
 	envPrefix string,
@@ -40,9 +44,11 @@ func New(
 		collectionRepo: collectionRepo,
 		cardRepo:       cardRepo,
 		userRepo:       userRepo,
+		importJobRepo:  importJobRepo,
 		gcsClient:      gcsClient,
 		bucketName:     bucketName,
 		envPrefix:      envPrefix,
+		importJobSlots: make(chan struct{}, importJobConcurrency),
 	}
 }
 
@@ -114,11 +120,16 @@ func (uc *usecase) GetMyCollections(userId uuid.UUID) ([]*entity.UserCollectionR
 			Name:             collection.Name,
 			AuthorName:       "You",
 			Topics:           collection.Topics,
+			SourceLanguage:   collection.SourceLanguage,
+			TargetLanguage:   collection.TargetLanguage,
+			Level:            collection.Level,
 			TotalCards:       totalCards,
 			Starred:          collectionUserMetrics.Starred,
 			Likes:            collectionMetrics.Likes,
 			Dislikes:         collectionMetrics.Dislikes,
 			Views:            collectionMetrics.Views,
+			AverageRating:    collectionMetrics.AverageRating,
+			Ratings:          collectionMetrics.Ratings,
 			Mastered:         collectionUserProgress.Mastered,
 			Reviewing:        collectionUserProgress.Reviewing,
 			Learning:         collectionUserProgress.Learning,
@@ -176,22 +187,26 @@ func (uc *usecase) GetCollectionFullUserMetrics(id, userId uuid.UUID) (*entity.C
 	return collectionResponse, nil
 }
 
-func (uc *usecase) GetRecommendedCollectionsPreview(userId uuid.UUID, page, size int) ([]*entity.UserCollectionResponse, error) {
+func (uc *usecase) GetRecommendedCollectionsPreview(userId uuid.UUID, page, size int, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.UserCollectionResponse, error) {
+	if err := searchFilterErrors.Of(filter.Normalize()); err != nil {
+		return nil, err
+	}
 	collectionResponses := []*entity.UserCollectionResponse{}
 	// var err error
 	limit := size
 	offset := (page - 1) * size
 
-	collections, err := uc.collectionRepo.GetRecommendedCollectionsPreview(userId, limit, offset)
+	recommendations, err := uc.recommendCollections(userId, limit, offset, sort, filter)
 	if err != nil {
 		if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
 			return nil, ErrNotFound
 		}
-		logrus.Errorf("%w: %v", ErrUnexpected, err)
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
 		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 	}
 
-	for _, collection := range collections {
+	for _, recommendation := range recommendations {
+		collection := recommendation.Collection
 		collectionAuthor, err := uc.userRepo.GetUserById(collection.AuthorId)
 		if err != nil {
 			if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
@@ -254,10 +269,15 @@ func (uc *usecase) GetRecommendedCollectionsPreview(userId uuid.UUID, page, size
 			Name:             collection.Name,
 			AuthorName:       collectionAuthor.Username,
 			Topics:           collection.Topics,
+			SourceLanguage:   collection.SourceLanguage,
+			TargetLanguage:   collection.TargetLanguage,
+			Level:            collection.Level,
 			TotalCards:       totalCards,
 			Likes:            collectionMetrics.Likes,
 			Dislikes:         collectionMetrics.Dislikes,
 			Views:            collectionMetrics.Views,
+			AverageRating:    collectionMetrics.AverageRating,
+			Ratings:          collectionMetrics.Ratings,
 			Mastered:         collectionUserProgress.Mastered,
 			Reviewing:        collectionUserProgress.Reviewing,
 			Learning:         collectionUserProgress.Learning,
@@ -266,6 +286,7 @@ func (uc *usecase) GetRecommendedCollectionsPreview(userId uuid.UUID, page, size
 			IsDislikedByUser: collectionUserMetrics.Disliked,
 			IsViewedByUser:   collectionUserMetrics.Viewed,
 			CreatedDate:      createdDateFormat,
+			Reason:           recommendation.Reason,
 		}
 
 		collectionResponses = append(collectionResponses, collectionResponse)
@@ -344,11 +365,16 @@ func (uc *usecase) GetLikedCollectionsPreview(userId uuid.UUID) ([]*entity.UserC
 			Name:             collection.Name,
 			AuthorName:       collectionAuthor.Name,
 			Topics:           collection.Topics,
+			SourceLanguage:   collection.SourceLanguage,
+			TargetLanguage:   collection.TargetLanguage,
+			Level:            collection.Level,
 			TotalCards:       totalCards,
 			Starred:          collectionUserMetrics.Starred,
 			Likes:            collectionMetrics.Likes,
 			Dislikes:         collectionMetrics.Dislikes,
 			Views:            collectionMetrics.Views,
+			AverageRating:    collectionMetrics.AverageRating,
+			Ratings:          collectionMetrics.Ratings,
 			Mastered:         collectionUserProgress.Mastered,
 			Reviewing:        collectionUserProgress.Reviewing,
 			Learning:         collectionUserProgress.Learning,
@@ -434,11 +460,16 @@ func (uc *usecase) GetStarredCollectionsPreview(userId uuid.UUID) ([]*entity.Use
 			Name:             collection.Name,
 			AuthorName:       collectionAuthor.Name,
 			Topics:           collection.Topics,
+			SourceLanguage:   collection.SourceLanguage,
+			TargetLanguage:   collection.TargetLanguage,
+			Level:            collection.Level,
 			TotalCards:       totalCards,
 			Starred:          collectionUserMetrics.Starred,
 			Likes:            collectionMetrics.Likes,
 			Dislikes:         collectionMetrics.Dislikes,
 			Views:            collectionMetrics.Views,
+			AverageRating:    collectionMetrics.AverageRating,
+			Ratings:          collectionMetrics.Ratings,
 			Mastered:         collectionUserProgress.Mastered,
 			Reviewing:        collectionUserProgress.Reviewing,
 			Learning:         collectionUserProgress.Learning,
@@ -464,6 +495,9 @@ func (uc *usecase) GetCollectionWithCards(collectionId, userId uuid.UUID, page,
 		logrus.Errorf("%w: %v", ErrUnexpected, err)
 		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 	}
+	if collection.Hidden && collection.AuthorId != userId {
+		return nil, ErrNotFound
+	}
 	collectionProgress, err := uc.collectionRepo.GetCollectionUserProgress(collectionId, userId)
 	if err != nil {
 		if errors.Is(err, repositoryIntf.ErrCollectionUserProgressNotFound) {
@@ -487,15 +521,30 @@ func (uc *usecase) GetCollectionWithCards(collectionId, userId uuid.UUID, page,
 		logrus.Errorf("%w: %v", ErrUnexpected, err)
 		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 	}
+	err = uc.attachTopMnemonics(cards.CardForUser, userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	levelCounts, err := uc.collectionRepo.GetCollectionCardLevels(collectionId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	suggestedLevel := suggestLevel(levelCounts)
 	collectionResponses := &entity.GetCollectionWithCardsResponse{
-		Id:         collection.Id,
-		Name:       collection.Name,
-		Mastered:   collectionProgress.Mastered,
-		Reviewing:  collectionProgress.Reviewing,
-		Learning:   collectionProgress.Learning,
-		TotalCards: cards.Total,
-		Topics:     collection.Topics,
-		Cards:      cards.CardForUser,
+		Id:             collection.Id,
+		Name:           collection.Name,
+		Mastered:       collectionProgress.Mastered,
+		Reviewing:      collectionProgress.Reviewing,
+		Learning:       collectionProgress.Learning,
+		TotalCards:     cards.Total,
+		Topics:         collection.Topics,
+		SourceLanguage: collection.SourceLanguage,
+		TargetLanguage: collection.TargetLanguage,
+		Level:          collection.Level,
+		SuggestedLevel: suggestedLevel,
+		Cards:          cards.CardForUser,
 	}
 	return collectionResponses, nil
 }
@@ -552,6 +601,15 @@ func (uc *usecase) LikeCollectionById(id, userId uuid.UUID) (*entity.CollectionF
 		logrus.Errorf("%w: %v", ErrUnexpected, err)
 		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 	}
+	// A user has at most one like event per collection, unliking removes it
+	if isLiked {
+		err = uc.collectionRepo.DeleteCollectionEvents(id, userId, entity.CollectionEventType_Like)
+	} else {
+		err = uc.collectionRepo.CreateCollectionEvent(id, userId, entity.CollectionEventType_Like)
+	}
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+	}
 
 	metrics, err := uc.collectionRepo.GetCollectionMetrics(id)
 	if err != nil {
@@ -626,6 +684,10 @@ func (uc *usecase) DislikeCollectionById(id, userId uuid.UUID) (*entity.Collecti
 			logrus.Errorf("%w: %v", ErrUnexpected, err)
 			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 		}
+		err = uc.collectionRepo.DeleteCollectionEvents(id, userId, entity.CollectionEventType_Like)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+		}
 	}
 
 	err = uc.collectionRepo.CollectionDislikeInteraction(id, userId, isDisliked)
@@ -692,15 +754,22 @@ func (uc *usecase) ViewCollectionById(id, userId uuid.UUID) error {
 			logrus.Errorf("%w: %v", ErrUnexpected, err)
 			return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 		}
+		err = uc.collectionRepo.CreateCollectionEvent(id, userId, entity.CollectionEventType_View)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+		}
 	}
 	return nil
 }
 
-func (uc *usecase) SearchCollectionByName(text string, userId uuid.UUID) ([]*entity.UserCollectionResponse, error) {
+func (uc *usecase) SearchCollectionByName(text string, userId uuid.UUID, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.UserCollectionResponse, error) {
+	if err := searchFilterErrors.Of(filter.Normalize()); err != nil {
+		return nil, err
+	}
 
 	collectionResponses := []*entity.UserCollectionResponse{}
 	var err error
-	collections, err := uc.collectionRepo.SearchCollectionByName(text, userId)
+	collections, err := uc.collectionRepo.SearchCollectionByName(text, userId, sort, filter)
 	if err != nil {
 		if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
 			return nil, ErrNotFound
@@ -767,11 +836,16 @@ func (uc *usecase) SearchCollectionByName(text string, userId uuid.UUID) ([]*ent
 			Name:             collection.Name,
 			AuthorName:       collectionAuthor.Name,
 			Topics:           collection.Topics,
+			SourceLanguage:   collection.SourceLanguage,
+			TargetLanguage:   collection.TargetLanguage,
+			Level:            collection.Level,
 			TotalCards:       totalCards,
 			Starred:          collectionUserMetrics.Starred,
 			Likes:            collectionMetrics.Likes,
 			Dislikes:         collectionMetrics.Dislikes,
 			Views:            collectionMetrics.Views,
+			AverageRating:    collectionMetrics.AverageRating,
+			Ratings:          collectionMetrics.Ratings,
 			Mastered:         collectionUserProgress.Mastered,
 			Reviewing:        collectionUserProgress.Reviewing,
 			Learning:         collectionUserProgress.Learning,
@@ -788,9 +862,30 @@ func (uc *usecase) SearchCollectionByName(text string, userId uuid.UUID) ([]*ent
 
 }
 
-func (uc *usecase) CreateCollection(collection entity.Collection, cards []*entity.Card, userId uuid.UUID) error {
+func (uc *usecase) CreateCollection(collection entity.Collection, cards []*entity.Card, userId uuid.UUID, duplicates entity.DuplicatePolicy) error {
+	err := setCollectionLanguages(&collection, cards)
+	if err != nil {
+		return err
+	}
+	err = setCollectionLevels(&collection, cards)
+	if err != nil {
+		return err
+	}
+	err = normalizeCardBodies(cards)
+	if err != nil {
+		return err
+	}
+	duplicates, err = duplicatePolicy(duplicates)
+	if err != nil {
+		return err
+	}
+	resolved, err := uc.resolveDuplicates(userId, cards, duplicates)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
 	urlGCP := "https://storage.googleapis.com/flashcards-images"
-	for _, card := range cards {
+	for _, card := range resolved.Cards {
 		if !strings.Contains(card.ImageUrl, urlGCP) {
 			response, err := http.Get(card.ImageUrl)
 			if err != nil {
@@ -825,8 +920,12 @@ func (uc *usecase) CreateCollection(collection entity.Collection, cards []*entit
 
 		}
 	}
+	err = uc.storeCardsAudio(resolved.Cards)
+	if err != nil {
+		return err
+	}
 
-	_, err := uc.collectionRepo.CreateCollectionWithCards(collection, cards)
+	_, err = uc.collectionRepo.CreateCollectionWithCards(collection, resolved.Cards, resolved.ExistingCardIds)
 	if err != nil {
 		if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
 			logrus.Errorf("%w: %v", ErrUnexpected, err)
@@ -844,114 +943,54 @@ func (uc *usecase) UpdateCollectionUserProgress(id uuid.UUID, mastered, reviewin
 	panic("Not implemented")
 }
 
-func (uc *usecase) UploadCollectionWithFile(userId uuid.UUID, file multipart.File, filename string) (*entity.CreateMultipleCollectionResponse, error) {
-	f, err := excelize.OpenReader(file)
+// UploadCollectionWithFile stores the uploaded file as an import job that is processed in the background,
+// every sheet of a workbook becomes its own collection. The progress of the job is polled with GetImportJob
+func (uc *usecase) UploadCollectionWithFile(userId uuid.UUID, file multipart.File, filename string, duplicates entity.DuplicatePolicy) (*entity.ImportJob, error) {
+	duplicates, err := duplicatePolicy(duplicates)
 	if err != nil {
-		logrus.Errorf("%w: %v", ErrUnexpected, err)
 		return nil, err
 	}
-	defer func() error {
-		// Close the spreadsheet.
-		if err := f.Close(); err != nil {
-			fmt.Println(err)
-			logrus.Errorf("%w: %v", ErrUnexpected, err)
-		}
-		return err
-	}()
-
-	collectionEnt := entity.Collection{}
-	cards := []*entity.Card{}
-
-	collectionName, err := f.GetCellValue(f.GetSheetName(0), "B3")
+	data, err := io.ReadAll(file)
 	if err != nil {
-		logrus.Errorf("%w: %v", ErrUnexpected, err)
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
 	}
-	collectionTopics, err := f.GetCellValue(f.GetSheetName(0), "B4")
+	// The file is validated right away so an unreadable file is reported by the upload request
+	fileSheets, err := readTableSheets(bytes.NewReader(data), filename)
 	if err != nil {
-		logrus.Errorf("%w: %v", ErrUnexpected, err)
 		return nil, err
 	}
-	collectionEnt.Name = collectionName
-	collectionEnt.Topics = strings.Split(collectionTopics, ";")
-	collectionEnt.AuthorId = userId
-
-	rows, err := f.GetRows(f.GetSheetName(0))
+	sheets, err := parseTemplateSheets(fileSheets)
 	if err != nil {
-		fmt.Println(err)
-		logrus.Errorf("%w: %v", ErrUnexpected, err)
 		return nil, err
 	}
-	for rowI := 7; rowI < len(rows); rowI++ {
-		fmt.Println(rowI)
-		// Skip row that does not contain one of mandatory fields
-		if len(rows[rowI]) < 4 {
-			fmt.Println("rows[rowI] Length less than 4")
-			continue
-		}
-		card := &entity.Card{
-			Id:         uuid.New(),
-			Word:       rows[rowI][0],
-			Definition: rows[rowI][1],
-			Sentence:   rows[rowI][2],
-			ImageUrl:   rows[rowI][3],
-		}
-
-		// Download an image from URL and use GCS url
-		urlGCP := "https://storage.googleapis.com/flashcards-images"
-		if !strings.Contains(rows[rowI][3], urlGCP) {
-			response, err := http.Get(rows[rowI][3])
-			if err != nil {
-				logrus.Errorf("%w: %v", ErrUnexpected, err)
-				return nil, err
-			}
-			defer response.Body.Close()
-
-			// if response.StatusCode != 200 {
-			// 	logrus.Errorf("%w: %v", ErrUnexpected, err)
-			// 	return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Received non 200 response code")
-			// }
-
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*50)
-			defer cancel()
-
-			filenameToUpload := "card_images/" + strings.ReplaceAll(rows[rowI][0], " ", "+") + "--" + uuid.NewString()
-			fileURL := "https://storage.googleapis.com/" + uc.bucketName + "/" + uc.envPrefix + "/" + filenameToUpload
-			wc := uc.gcsClient.Bucket(uc.bucketName).Object(uc.envPrefix + "/" + filenameToUpload).NewWriter(ctx)
-			// wc.ACL = []storage.ACLRule{{Entity: storage.AllAuthenticatedUsers, Role: storage.RoleOwner}}
-			if _, err := io.Copy(wc, response.Body); err != nil {
-				logrus.Errorf("%w: %v", ErrUnexpected, err)
-				return nil, fmt.Errorf("%w: %v", "ErrUnexpected1", err)
-			}
-
-			if err := wc.Close(); err != nil {
-				logrus.Errorf("%w: %v", ErrUnexpected, err)
-				return nil, fmt.Errorf("%w: %v", "ErrUnexpected2", err)
-			}
-			card.ImageUrl = fileURL
+	for _, sheet := range sheets {
+		err = setCollectionLanguages(&sheet.Collection, sheet.Cards)
+		if err != nil {
+			return nil, sheetError(sheet.Name, err)
 		}
-
-		if len(rows[rowI]) >= 5 {
-			card.Antonyms = rows[rowI][4]
+		err = setCollectionLevels(&sheet.Collection, sheet.Cards)
+		if err != nil {
+			return nil, sheetError(sheet.Name, err)
 		}
-		if len(rows[rowI]) >= 6 {
-			card.Synonyms = rows[rowI][5]
+		err = normalizeCardBodies(sheet.Cards)
+		if err != nil {
+			return nil, sheetError(sheet.Name, err)
 		}
-		card.AuthorId = userId
-
-		cards = append(cards, card)
 	}
-	collection, err := uc.collectionRepo.CreateCollectionWithCards(collectionEnt, cards)
+
+	job, err := uc.importJobRepo.CreateImportJob(entity.ImportJob{
+		UserId:          userId,
+		Filename:        filename,
+		DuplicatePolicy: duplicates,
+		File:            data,
+	})
 	if err != nil {
-		fmt.Println(err)
-		logrus.Errorf("%w: %v", ErrUnexpected, err)
-		return nil, err
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 	}
-	logrus.Info("Uploaded successfully")
-	return &entity.CreateMultipleCollectionResponse{
-		Name:        collection.Name,
-		CardsAmount: uint32(len(cards)),
-	}, nil
+	job.File = data
+	go uc.runImportJob(job)
+	return job, nil
 }
 
 func (uc *usecase) UpdateCollection(
@@ -959,47 +998,79 @@ func (uc *usecase) UpdateCollection(
 	updateData *entity.UpdateCollectionRequest) error {
 
 	collectionData := entity.Collection{
-		Id:     updateData.Id,
-		Name:   updateData.Name,
-		Topics: updateData.Topics,
+		Id:             updateData.Id,
+		Name:           updateData.Name,
+		Topics:         updateData.Topics,
+		SourceLanguage: updateData.SourceLanguage,
+		TargetLanguage: updateData.TargetLanguage,
+		Level:          updateData.Level,
 	}
-	err := uc.collectionRepo.UpdateCollection(collectionData)
+	err := setCollectionLanguages(&collectionData, nil)
+	if err != nil {
+		return err
+	}
+	collectionData.Level, err = entity.NormalizeLevel(collectionData.Level)
+	if err != nil {
+		return ErrInvalidLevel
+	}
+	err = uc.collectionRepo.UpdateCollection(collectionData)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	// New cards inherit the languages the collection has after the update
+	collection, err := uc.getCollection(collectionData.Id)
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
 	cardsToCreate := []*entity.Card{}
+	cardsToEdit := []*entity.Card{}
 	cardsToRemove := []*entity.CollectionCards{}
 
 	for _, card := range updateData.Cards {
 		switch card.Action {
 		case entity.CardUpdateType_Create: // just create a new card
 			card := &entity.Card{
-				Word:       card.Word,
-				ImageUrl:   card.ImageUrl,
-				Definition: card.Definition,
-				Sentence:   card.Sentence,
-				Antonyms:   card.Antonyms,
-				Synonyms:   card.Synonyms,
+				Word:           card.Word,
+				ImageUrl:       card.ImageUrl,
+				AudioUrl:       card.AudioUrl,
+				Definition:     card.Definition,
+				Sentence:       card.Sentence,
+				Antonyms:       card.Antonyms,
+				Synonyms:       card.Synonyms,
+				SynonymList:    card.SynonymList,
+				AntonymList:    card.AntonymList,
+				Senses:         card.Senses,
+				Pronunciation:  card.Pronunciation,
+				Gender:         card.Gender,
+				Plural:         card.Plural,
+				SourceLanguage: card.SourceLanguage,
+				TargetLanguage: card.TargetLanguage,
+				Level:          card.Level,
 			}
 			cardsToCreate = append(cardsToCreate, card)
 
-		case entity.CardUpdateType_Update: // create a new card and remove the previous from the collection
-			cardToCreate := &entity.Card{
-				Word:       card.Word,
-				ImageUrl:   card.ImageUrl,
-				Definition: card.Definition,
-				Sentence:   card.Sentence,
-				Antonyms:   card.Antonyms,
-				Synonyms:   card.Synonyms,
-			}
-			cardsToCreate = append(cardsToCreate, cardToCreate)
-
-			cardToRemove := &entity.CollectionCards{
-				CardId:       card.Id,
-				CollectionId: collectionData.Id,
+		case entity.CardUpdateType_Update: // edit the card, the progress of its learners is kept
+			cardToEdit := &entity.Card{
+				Id:             card.Id,
+				Word:           card.Word,
+				ImageUrl:       card.ImageUrl,
+				AudioUrl:       card.AudioUrl,
+				Definition:     card.Definition,
+				Sentence:       card.Sentence,
+				Antonyms:       card.Antonyms,
+				Synonyms:       card.Synonyms,
+				SynonymList:    card.SynonymList,
+				AntonymList:    card.AntonymList,
+				Senses:         card.Senses,
+				Pronunciation:  card.Pronunciation,
+				Gender:         card.Gender,
+				Plural:         card.Plural,
+				SourceLanguage: card.SourceLanguage,
+				TargetLanguage: card.TargetLanguage,
+				Level:          card.Level,
 			}
-			cardsToRemove = append(cardsToRemove, cardToRemove)
+			cardsToEdit = append(cardsToEdit, cardToEdit)
 
 		case entity.CardUpdateType_Remove: // remove the card from the collection, do not delete it
 			card := &entity.CollectionCards{
@@ -1011,12 +1082,52 @@ func (uc *usecase) UpdateCollection(
 		}
 	}
 	if len(cardsToCreate) > 0 {
+		err = setCollectionLanguages(collection, cardsToCreate)
+		if err != nil {
+			return err
+		}
+		err = normalizeCardLevels(cardsToCreate)
+		if err != nil {
+			return err
+		}
+		err = normalizeCardBodies(cardsToCreate)
+		if err != nil {
+			return err
+		}
+		err = uc.storeCardsAudio(cardsToCreate)
+		if err != nil {
+			return err
+		}
 		err = uc.cardRepo.CreateMultipleCards(collectionData.Id, cardsToCreate, userId)
 		if err != nil {
 			fmt.Println(err)
 			return err
 		}
 	}
+	if len(cardsToEdit) > 0 {
+		err = setCollectionLanguages(collection, cardsToEdit)
+		if err != nil {
+			return err
+		}
+		err = normalizeCardLevels(cardsToEdit)
+		if err != nil {
+			return err
+		}
+		err = normalizeCardBodies(cardsToEdit)
+		if err != nil {
+			return err
+		}
+		err = uc.storeCardsAudio(cardsToEdit)
+		if err != nil {
+			return err
+		}
+		for _, card := range cardsToEdit {
+			err = uc.editCollectionCard(userId, collectionData.Id, card, updateData.ForkSharedCards)
+			if err != nil {
+				return err
+			}
+		}
+	}
 	if len(cardsToRemove) > 0 {
 		err = uc.cardRepo.RemoveMultipleCardsFromCollection(cardsToRemove)
 		if err != nil {
@@ -1027,13 +1138,16 @@ func (uc *usecase) UpdateCollection(
 	return nil
 }
 
-func (uc *usecase) GetRecommendedCollectionsPreviewForUnregistered(page, size int) ([]*entity.UserCollectionResponse, error) {
+func (uc *usecase) GetRecommendedCollectionsPreviewForUnregistered(page, size int, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.UserCollectionResponse, error) {
+	if err := searchFilterErrors.Of(filter.Normalize()); err != nil {
+		return nil, err
+	}
 	collectionResponses := []*entity.UserCollectionResponse{}
 	// var err error
 	limit := size
 	offset := (page - 1) * size
 
-	collections, err := uc.collectionRepo.GetRecommendedCollectionsPreviewForUnregistered(limit, offset)
+	collections, err := uc.collectionRepo.GetRecommendedCollectionsPreviewForUnregistered(limit, offset, sort, filter)
 	if err != nil {
 		if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
 			return nil, ErrNotFound
@@ -1078,10 +1192,15 @@ func (uc *usecase) GetRecommendedCollectionsPreviewForUnregistered(page, size in
 			Name:             collection.Name,
 			AuthorName:       collectionAuthor.Name,
 			Topics:           collection.Topics,
+			SourceLanguage:   collection.SourceLanguage,
+			TargetLanguage:   collection.TargetLanguage,
+			Level:            collection.Level,
 			TotalCards:       totalCards,
 			Likes:            collectionMetrics.Likes,
 			Dislikes:         collectionMetrics.Dislikes,
 			Views:            collectionMetrics.Views,
+			AverageRating:    collectionMetrics.AverageRating,
+			Ratings:          collectionMetrics.Ratings,
 			Mastered:         0,
 			Reviewing:        0,
 			Learning:         0,
@@ -1108,6 +1227,9 @@ func (uc *usecase) GetCollectionWithCardsForUnregistered(collectionId uuid.UUID,
 		logrus.Errorf("%w: %v", ErrUnexpected, err)
 		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 	}
+	if collection.Hidden {
+		return nil, ErrNotFound
+	}
 
 	limit := size
 	offset := (page - 1) * size
@@ -1119,23 +1241,41 @@ func (uc *usecase) GetCollectionWithCardsForUnregistered(collectionId uuid.UUID,
 		logrus.Errorf("%w: %v", ErrUnexpected, err)
 		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
 	}
+	err = uc.attachTopMnemonics(cards.CardForUser, uuid.Nil)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	levelCounts, err := uc.collectionRepo.GetCollectionCardLevels(collectionId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	suggestedLevel := suggestLevel(levelCounts)
 	collectionResponses := &entity.GetCollectionWithCardsResponse{
-		Id:         collection.Id,
-		Name:       collection.Name,
-		Mastered:   0,
-		Reviewing:  0,
-		Learning:   0,
-		TotalCards: cards.Total,
-		Topics:     collection.Topics,
-		Cards:      cards.CardForUser,
+		Id:             collection.Id,
+		Name:           collection.Name,
+		Mastered:       0,
+		Reviewing:      0,
+		Learning:       0,
+		TotalCards:     cards.Total,
+		Topics:         collection.Topics,
+		SourceLanguage: collection.SourceLanguage,
+		TargetLanguage: collection.TargetLanguage,
+		Level:          collection.Level,
+		SuggestedLevel: suggestedLevel,
+		Cards:          cards.CardForUser,
 	}
 	return collectionResponses, nil
 }
 
-func (uc *usecase) SearchCollectionByNameForUnregistered(text string) ([]*entity.UserCollectionResponse, error) {
+func (uc *usecase) SearchCollectionByNameForUnregistered(text string, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.UserCollectionResponse, error) {
+	if err := searchFilterErrors.Of(filter.Normalize()); err != nil {
+		return nil, err
+	}
 	collectionResponses := []*entity.UserCollectionResponse{}
 	var err error
-	collections, err := uc.collectionRepo.SearchCollectionByNameForUnregistered(text)
+	collections, err := uc.collectionRepo.SearchCollectionByNameForUnregistered(text, sort, filter)
 	if err != nil {
 		if errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
 			return nil, ErrNotFound
@@ -1181,11 +1321,16 @@ func (uc *usecase) SearchCollectionByNameForUnregistered(text string) ([]*entity
 			Name:             collection.Name,
 			AuthorName:       collectionAuthor.Name,
 			Topics:           collection.Topics,
+			SourceLanguage:   collection.SourceLanguage,
+			TargetLanguage:   collection.TargetLanguage,
+			Level:            collection.Level,
 			TotalCards:       totalCards,
 			Starred:          false,
 			Likes:            collectionMetrics.Likes,
 			Dislikes:         collectionMetrics.Dislikes,
 			Views:            collectionMetrics.Views,
+			AverageRating:    collectionMetrics.AverageRating,
+			Ratings:          collectionMetrics.Ratings,
 			Mastered:         0,
 			Reviewing:        0,
 			Learning:         0,
@@ -0,0 +1,314 @@
+package collection_usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	importJobConcurrency = 2
+	// Progress is saved after every row with an image and at least once per this many rows
+	importJobSaveInterval = 50
+	// Only the first errors are kept, the failed rows counter still counts all of them
+	importJobMaxErrors = 100
+)
+
+// errImportJobCancelled stops a job that was cancelled while it was running
+var errImportJobCancelled = errors.New("import job cancelled")
+
+// importProgress counts the processed rows of a running job and saves them to the job table
+type importProgress struct {
+	uc      *usecase
+	job     *entity.ImportJob
+	unsaved int
+}
+
+// ResumeImportJobs restarts the jobs that were pending or running when the server stopped.
+// The sheets whose result was saved are skipped, the other sheets are imported from their first row.
+func (uc *usecase) ResumeImportJobs() {
+	jobs, err := uc.importJobRepo.GetUnfinishedImportJobs()
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return
+	}
+	for _, job := range jobs {
+		job.ProcessedRows = 0
+		job.FailedRows = 0
+		job.Errors = []string{}
+		go uc.runImportJob(job)
+	}
+}
+
+func (uc *usecase) GetImportJob(id, userId uuid.UUID) (*entity.ImportJob, error) {
+	job, err := uc.importJobRepo.GetImportJob(id)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrImportJobNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if job.UserId != userId {
+		return nil, ErrUnauthorized
+	}
+	return job, nil
+}
+
+func (uc *usecase) CancelImportJob(id, userId uuid.UUID) (*entity.ImportJob, error) {
+	_, err := uc.GetImportJob(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	cancelled, err := uc.importJobRepo.CancelImportJob(id)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if !cancelled {
+		return nil, ErrImportJobFinished
+	}
+	return uc.GetImportJob(id, userId)
+}
+
+func (uc *usecase) runImportJob(job *entity.ImportJob) {
+	uc.importJobSlots <- struct{}{}
+	defer func() { <-uc.importJobSlots }()
+
+	progress := &importProgress{uc: uc, job: job}
+	job.Status = entity.ImportJobStatus_Running
+	if err := progress.save(); err != nil {
+		return
+	}
+
+	results, err := uc.importCollectionFile(job, progress)
+	if errors.Is(err, errImportJobCancelled) {
+		return
+	}
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	job.Results = results
+	if err != nil {
+		job.Status = entity.ImportJobStatus_Failed
+		if errors.Is(err, ErrUnexpected) {
+			logrus.Errorf("%v: import job %v: %v", ErrUnexpected, job.Id, err)
+			err = ErrUnexpected
+		}
+		job.Errors = append(job.Errors, err.Error())
+	} else {
+		job.Status = entity.ImportJobStatus_Completed
+	}
+	// A job cancelled after its collections were created stays cancelled, the collections are kept
+	progress.save()
+}
+
+// importCollectionFile imports every sheet of the file as its own collection, a sheet that fails
+// is reported in its result and the other sheets are imported anyway.
+// The job fails only when none of the sheets could be imported.
+func (uc *usecase) importCollectionFile(job *entity.ImportJob, progress *importProgress) ([]*entity.CreateMultipleCollectionResponse, error) {
+	data := job.File
+	if data == nil {
+		var err error
+		data, err = uc.importJobRepo.GetImportJobFile(job.Id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, err)
+		}
+	}
+	fileSheets, err := readTableSheets(bytes.NewReader(data), job.Filename)
+	if err != nil {
+		return nil, err
+	}
+	sheets, err := parseTemplateSheets(fileSheets)
+	if err != nil {
+		return nil, err
+	}
+	job.TotalRows = 0
+	for _, sheet := range sheets {
+		job.TotalRows += len(sheet.Cards)
+	}
+
+	// The sheets finished before the server stopped keep their result and are not imported again
+	finished := map[string]*entity.CreateMultipleCollectionResponse{}
+	for _, result := range job.Results {
+		finished[result.Sheet] = result
+	}
+	results := []*entity.CreateMultipleCollectionResponse{}
+	imported := 0
+	var firstErr error
+	for _, sheet := range sheets {
+		if result, ok := finished[sheet.Name]; ok {
+			job.ProcessedRows += len(sheet.Cards)
+			if result.Error == "" {
+				imported++
+			} else if firstErr == nil {
+				firstErr = sheetError(sheet.Name, errors.New(result.Error))
+			}
+			results = append(results, result)
+			continue
+		}
+		result, err := uc.importCollectionSheet(job, sheet.Collection, sheet.Cards, progress)
+		if errors.Is(err, errImportJobCancelled) {
+			return nil, err
+		}
+		if err != nil {
+			if errors.Is(err, ErrUnexpected) {
+				logrus.Errorf("%v: import job %v: %v", ErrUnexpected, job.Id, err)
+				err = ErrUnexpected
+			}
+			result = &entity.CreateMultipleCollectionResponse{
+				Name:  sheet.Collection.Name,
+				Error: err.Error(),
+			}
+			if firstErr == nil {
+				firstErr = sheetError(sheet.Name, err)
+			}
+		} else {
+			imported++
+		}
+		result.Sheet = sheet.Name
+		results = append(results, result)
+		// The result is saved as soon as the sheet is done, a resumed job does not create its collection twice
+		job.Results = results
+		err = progress.save()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if imported == 0 {
+		return results, firstErr
+	}
+	return results, nil
+}
+
+// importCollectionSheet creates a collection from a sheet of the upload template, a sheet exported from
+// a collection of the user updates that collection instead
+func (uc *usecase) importCollectionSheet(
+	job *entity.ImportJob,
+	collectionEnt entity.Collection,
+	cards []*entity.Card,
+	progress *importProgress,
+) (*entity.CreateMultipleCollectionResponse, error) {
+	var err error
+	collectionEnt.AuthorId = job.UserId
+	if collectionEnt.Id != uuid.Nil {
+		collection, err := uc.getCollection(collectionEnt.Id)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		if err == nil && collection.AuthorId == job.UserId {
+			return uc.updateCollectionFromTemplate(job.UserId, collection, collectionEnt, cards, progress)
+		}
+		collectionEnt.Id = uuid.Nil
+	}
+
+	err = normalizeCardBodies(cards)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := uc.resolveDuplicates(job.UserId, cards, job.DuplicatePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, err)
+	}
+	// The rows of skipped and merged cards are done right away
+	for i := len(resolved.Cards); i < len(cards); i++ {
+		err = progress.row(false, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+	cards = resolved.Cards
+	for _, card := range cards {
+		card.Id = uuid.New()
+		card.AuthorId = job.UserId
+		problem := ""
+		if card.ImageUrl != "" {
+			// Download an image from URL and use GCS url
+			card.ImageUrl, err = uc.storeImageFromUrl(card.ImageUrl, card.Word)
+			if err != nil {
+				problem = fmt.Sprintf("%s: image could not be downloaded", card.Word)
+				card.ImageUrl = ""
+			}
+		}
+		slow := card.ImageUrl != "" || problem != ""
+		if card.AudioUrl != "" {
+			slow = true
+			card.AudioUrl, err = uc.storeAudioFromUrl(card.AudioUrl, card.Word)
+			if err != nil {
+				problem = joinProblems(problem, fmt.Sprintf("%s: audio could not be downloaded", card.Word))
+				card.AudioUrl = ""
+			}
+		}
+		err = progress.row(slow, problem)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = setCollectionLanguages(&collectionEnt, cards)
+	if err != nil {
+		return nil, err
+	}
+	err = setCollectionLevels(&collectionEnt, cards)
+	if err != nil {
+		return nil, err
+	}
+	// The job may have been cancelled during the last rows
+	err = progress.save()
+	if err != nil {
+		return nil, err
+	}
+	collection, err := uc.collectionRepo.CreateCollectionWithCards(collectionEnt, cards, resolved.ExistingCardIds)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, err)
+	}
+	return &entity.CreateMultipleCollectionResponse{
+		Name:        collection.Name,
+		CardsAmount: resolved.amount(),
+		Duplicates:  resolved.Duplicates,
+	}, nil
+}
+
+// row marks the next row as processed, problem describes why the row failed,
+// slow rows (the ones with an image) are saved right away so the progress moves while polling
+func (p *importProgress) row(slow bool, problem string) error {
+	p.job.ProcessedRows++
+	if problem != "" {
+		p.job.FailedRows++
+		if len(p.job.Errors) < importJobMaxErrors {
+			p.job.Errors = append(p.job.Errors, problem)
+		}
+	}
+	p.unsaved++
+	if slow || p.unsaved >= importJobSaveInterval {
+		return p.save()
+	}
+	return nil
+}
+
+// joinProblems reports the problems of a row as one error of the job
+func joinProblems(problem, other string) string {
+	if problem == "" {
+		return other
+	}
+	return problem + "; " + other
+}
+
+// save stores the progress of the job, it returns errImportJobCancelled when the job was cancelled
+func (p *importProgress) save() error {
+	p.unsaved = 0
+	saved, err := p.uc.importJobRepo.UpdateImportJob(*p.job)
+	if err != nil {
+		// The job goes on, the progress is saved again with the next rows
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil
+	}
+	if !saved {
+		return errImportJobCancelled
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package collection_usecase
 
 import (
 	"errors"
+	"io"
 	"mime/multipart"
+	"time"
 
 	"github.com/flash-cards-vocab/backend/entity"
 	"github.com/google/uuid"
@@ -12,10 +14,37 @@ var ErrUnexpected = errors.New("Internal error")
 var ErrUnauthorized = errors.New("ErrUnauthorized")
 var ErrNotFound = errors.New("ErrNotFound")
 var ErrForbiddenSelfRequest = errors.New("Self request is forbidden")
+var ErrInvalidTrendingWindow = errors.New("Trending window must be one of day, week, month")
+var ErrInvalidSort = errors.New("Sort must be one of relevance, rating")
+var ErrInvalidRating = errors.New("Rating must be between 1 and 5")
+var ErrReviewNotFound = errors.New("Review not found")
+var ErrInvalidLanguage = errors.New("Language must be a valid BCP-47 tag")
+var ErrInvalidLevel = errors.New("Level must be at most 30 characters")
+var ErrInvalidAnkiPackage = errors.New("Invalid Anki package")
+var ErrEmptyImport = errors.New("No cards could be imported")
+var ErrInvalidExportFormat = errors.New("Export format must be one of apkg, xlsx, csv, pdf")
+var ErrInvalidPdfOptions = errors.New("PDF layout must be one of cards, list and cards per page one of 1, 2, 4, 6, 8, 10, 12")
+var ErrInvalidImportFile = errors.New("Invalid import file")
+var ErrInvalidColumnMapping = errors.New("Invalid column mapping")
+var ErrInvalidSeparator = errors.New("Separators must be one of tab, dash, comma, semicolon, newline, blank_line, custom and differ from each other")
+var ErrTooManyCards = errors.New("Too many cards in one import")
+var ErrEmptyCollectionName = errors.New("Collection name is required")
+var ErrImportJobFinished = errors.New("Import job is already finished")
+var ErrInvalidDuplicatePolicy = errors.New("Duplicates must be one of keep, skip, merge")
+var ErrInvalidAudio = errors.New("Audio must be an MP3, OGG or WAV file of at most 30 seconds and 5 MB")
+var ErrInvalidPartOfSpeech = errors.New("Part of speech must be one of noun, verb, adjective, adverb, pronoun, preposition, conjunction, interjection, determiner, numeral, particle, phrase")
+var ErrInvalidCardBody = errors.New("Cards have at most 20 senses of 10 examples, pronunciation and plural at most 150 characters and gender at most 30")
+
+// searchFilterErrors maps the errors of entity.SearchFilter.Normalize to the usecase errors
+var searchFilterErrors = entity.SearchFilterErrors{
+	Language:     ErrInvalidLanguage,
+	Level:        ErrInvalidLevel,
+	PartOfSpeech: ErrInvalidPartOfSpeech,
+}
 
 type UseCase interface {
 	GetMyCollections(userId uuid.UUID) ([]*entity.UserCollectionResponse, error)
-	GetRecommendedCollectionsPreview(userId uuid.UUID, page, size int) ([]*entity.UserCollectionResponse, error)
+	GetRecommendedCollectionsPreview(userId uuid.UUID, page, size int, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.UserCollectionResponse, error)
 	GetLikedCollectionsPreview(userId uuid.UUID) ([]*entity.UserCollectionResponse, error)
 	GetStarredCollectionsPreview(userId uuid.UUID) ([]*entity.UserCollectionResponse, error)
 	GetCollectionWithCards(id, userId uuid.UUID, page, size int) (*entity.GetCollectionWithCardsResponse, error)
@@ -26,15 +55,31 @@ type UseCase interface {
 	LikeCollectionById(id, userId uuid.UUID) (*entity.CollectionFullUserMetricsResponse, error)
 	DislikeCollectionById(id, userId uuid.UUID) (*entity.CollectionFullUserMetricsResponse, error)
 	ViewCollectionById(id, userId uuid.UUID) error
-	SearchCollectionByName(text string, userId uuid.UUID) ([]*entity.UserCollectionResponse, error)
-	CreateCollection(collection entity.Collection, cards []*entity.Card, userId uuid.UUID) error
+	SearchCollectionByName(text string, userId uuid.UUID, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.UserCollectionResponse, error)
+	CreateCollection(collection entity.Collection, cards []*entity.Card, userId uuid.UUID, duplicates entity.DuplicatePolicy) error
 	UpdateCollectionUserProgress(id uuid.UUID, mastered, reviewing, learning uint32) error
 
-	UploadCollectionWithFile(userId uuid.UUID, file multipart.File, filename string) (*entity.CreateMultipleCollectionResponse, error)
+	UploadCollectionWithFile(userId uuid.UUID, file multipart.File, filename string, duplicates entity.DuplicatePolicy) (*entity.ImportJob, error)
+	GetImportJob(id, userId uuid.UUID) (*entity.ImportJob, error)
+	CancelImportJob(id, userId uuid.UUID) (*entity.ImportJob, error)
+	ResumeImportJobs()
 	UpdateCollection(userId uuid.UUID, updateData *entity.UpdateCollectionRequest) error
+	ImportAnkiCollection(userId uuid.UUID, collection entity.Collection, file io.ReaderAt, size int64, duplicates entity.DuplicatePolicy) (*entity.ImportReport, error)
+	ExportCollection(id, userId uuid.UUID, options entity.ExportOptions) (*entity.ExportFile, error)
+	ImportTable(userId uuid.UUID, collection entity.Collection, file io.Reader, filename string, options entity.TableImportOptions) (*entity.TableImportResult, error)
+	ImportText(userId uuid.UUID, request entity.TextImportRequest) (*entity.TextImportResult, error)
+
+	GetTrendingCollections(userId uuid.UUID, window entity.TrendingWindow, page, size int) ([]*entity.UserCollectionResponse, error)
+	RefreshTrendingCollections() error
+	StartTrendingRefresh(interval time.Duration)
+
+	ReviewCollection(id, userId uuid.UUID, review entity.CollectionReviewRequest) (*entity.CollectionReview, error)
+	DeleteCollectionReview(id, userId uuid.UUID) error
+	ReplyToCollectionReview(reviewId, userId uuid.UUID, reply string) error
+	GetCollectionReviews(id uuid.UUID, page, size int) (*entity.CollectionReviewPagination, error)
 
 	// Open routes
-	GetRecommendedCollectionsPreviewForUnregistered(page, size int) ([]*entity.UserCollectionResponse, error)
+	GetRecommendedCollectionsPreviewForUnregistered(page, size int, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.UserCollectionResponse, error)
 	GetCollectionWithCardsForUnregistered(id uuid.UUID, page, size int) (*entity.GetCollectionWithCardsResponse, error)
-	SearchCollectionByNameForUnregistered(text string) ([]*entity.UserCollectionResponse, error)
+	SearchCollectionByNameForUnregistered(text string, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.UserCollectionResponse, error)
 }
@@ -0,0 +1,36 @@
+package collection_usecase
+
+import (
+	"github.com/flash-cards-vocab/backend/entity"
+)
+
+// setCollectionLanguages normalizes the collection language tags and lets every card
+// without its own languages inherit them from the collection
+func setCollectionLanguages(collection *entity.Collection, cards []*entity.Card) error {
+	var err error
+	collection.SourceLanguage, err = entity.NormalizeLanguageTag(collection.SourceLanguage)
+	if err != nil {
+		return ErrInvalidLanguage
+	}
+	collection.TargetLanguage, err = entity.NormalizeLanguageTag(collection.TargetLanguage)
+	if err != nil {
+		return ErrInvalidLanguage
+	}
+	for _, card := range cards {
+		card.SourceLanguage, err = entity.NormalizeLanguageTag(card.SourceLanguage)
+		if err != nil {
+			return ErrInvalidLanguage
+		}
+		card.TargetLanguage, err = entity.NormalizeLanguageTag(card.TargetLanguage)
+		if err != nil {
+			return ErrInvalidLanguage
+		}
+		if card.SourceLanguage == "" {
+			card.SourceLanguage = collection.SourceLanguage
+		}
+		if card.TargetLanguage == "" {
+			card.TargetLanguage = collection.TargetLanguage
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package collection_usecase
+
+import (
+	"github.com/flash-cards-vocab/backend/entity"
+)
+
+// setCollectionLevels normalizes the collection and card levels, a collection without a level
+// gets the level suggested by its cards
+func setCollectionLevels(collection *entity.Collection, cards []*entity.Card) error {
+	var err error
+	collection.Level, err = entity.NormalizeLevel(collection.Level)
+	if err != nil {
+		return ErrInvalidLevel
+	}
+	err = normalizeCardLevels(cards)
+	if err != nil {
+		return err
+	}
+	if collection.Level == "" {
+		collection.Level = suggestLevel(countCardLevels(cards))
+	}
+	return nil
+}
+
+func normalizeCardLevels(cards []*entity.Card) error {
+	var err error
+	for _, card := range cards {
+		card.Level, err = entity.NormalizeLevel(card.Level)
+		if err != nil {
+			return ErrInvalidLevel
+		}
+	}
+	return nil
+}
+
+func countCardLevels(cards []*entity.Card) []*entity.LevelCount {
+	counts := []*entity.LevelCount{}
+	indexes := map[string]int{}
+	for _, card := range cards {
+		if card.Level == "" {
+			continue
+		}
+		i, ok := indexes[card.Level]
+		if !ok {
+			i = len(counts)
+			indexes[card.Level] = i
+			counts = append(counts, &entity.LevelCount{Level: card.Level})
+		}
+		counts[i].Count++
+	}
+	return counts
+}
+
+// suggestLevel returns the median CEFR level of the cards when at least half of the labelled cards use CEFR,
+// otherwise the most common custom level. Returns empty string when no card has a level.
+func suggestLevel(counts []*entity.LevelCount) string {
+	cefrCounts := make([]int, len(entity.CEFRLevels))
+	cefrTotal, total := 0, 0
+	customLevel, customCount := "", 0
+	for _, count := range counts {
+		total += count.Count
+		i := entity.CEFRLevelIndex(count.Level)
+		if i >= 0 {
+			cefrCounts[i] += count.Count
+			cefrTotal += count.Count
+		} else if count.Count > customCount {
+			customLevel, customCount = count.Level, count.Count
+		}
+	}
+	if total == 0 {
+		return ""
+	}
+	if cefrTotal*2 < total {
+		return customLevel
+	}
+	median := (cefrTotal + 1) / 2
+	for i, count := range cefrCounts {
+		median -= count
+		if median <= 0 {
+			return entity.CEFRLevels[i]
+		}
+	}
+	return ""
+}
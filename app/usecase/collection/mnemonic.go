@@ -0,0 +1,29 @@
+package collection_usecase
+
+import (
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+// topMnemonicsPerCard is the number of community mnemonics returned with every card of a collection
+const topMnemonicsPerCard = 3
+
+// attachTopMnemonics sets the best voted community mnemonics of the cards
+func (uc *usecase) attachTopMnemonics(cards []*entity.CardForUser, userId uuid.UUID) error {
+	cardIds := []uuid.UUID{}
+	for _, card := range cards {
+		cardIds = append(cardIds, card.Id)
+	}
+	mnemonics, err := uc.cardRepo.GetTopCardMnemonics(cardIds, userId, topMnemonicsPerCard)
+	if err != nil {
+		return err
+	}
+	byCard := map[uuid.UUID][]*entity.CardMnemonic{}
+	for _, mnemonic := range mnemonics {
+		byCard[mnemonic.CardId] = append(byCard[mnemonic.CardId], mnemonic)
+	}
+	for _, card := range cards {
+		card.TopMnemonics = byCard[card.Id]
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+package collection_usecase
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/go-pdf/fpdf"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	_ "golang.org/x/image/webp"
+)
+
+// Sizes of the printable PDF export, lengths are in millimeters and font sizes in points
+const (
+	pdfFontFamily     = "go"
+	pdfPageMargin     = 10.0
+	pdfCardPadding    = 4.0
+	pdfImageGap       = 3.0
+	pdfMinFontSize    = 6.0
+	pdfListImageSize  = 25.0
+	pdfListEntryGap   = 3.0
+	pdfLineHeightRate = 1.25
+)
+
+// Columns and rows of the cards layout for every supported amount of cards per page
+var pdfGrids = map[int][2]int{
+	1:  {1, 1},
+	2:  {1, 2},
+	4:  {2, 2},
+	6:  {2, 3},
+	8:  {2, 4},
+	10: {2, 5},
+	12: {3, 4},
+}
+
+type pdfImage struct {
+	name   string
+	width  float64
+	height float64
+}
+
+// pdfBlock is a paragraph of a card, scale is its font size relative to the other paragraphs
+type pdfBlock struct {
+	style string
+	scale float64
+	text  string
+}
+
+func validPdfOptions(options entity.ExportOptions) bool {
+	if !options.PdfLayout.IsValid() {
+		return false
+	}
+	if options.PdfLayout == entity.PdfLayout_List {
+		return true
+	}
+	_, ok := pdfGrids[options.CardsPerPage]
+	return ok
+}
+
+func (uc *usecase) exportPdf(collection *entity.Collection, cards []*entity.CardForUser, options entity.ExportOptions) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(collection.Name, true)
+	pdf.SetMargins(pdfPageMargin, pdfPageMargin, pdfPageMargin)
+	pdf.SetAutoPageBreak(false, pdfPageMargin)
+	// The Go fonts cover Latin, Greek and Cyrillic, the standard PDF fonts only cover Western European languages
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "B", gobold.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "I", goitalic.TTF)
+
+	images := uc.registerPdfImages(pdf, cards)
+	if options.PdfLayout == entity.PdfLayout_List {
+		writePdfList(pdf, collection, cards, images)
+	} else {
+		writePdfCards(pdf, cards, images, pdfGrids[options.CardsPerPage])
+	}
+
+	buf := &bytes.Buffer{}
+	err := pdf.Output(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// registerPdfImages adds the card images to the document once per URL,
+// a card is still exported when its image is not available
+func (uc *usecase) registerPdfImages(pdf *fpdf.Fpdf, cards []*entity.CardForUser) map[string]*pdfImage {
+	images := map[string]*pdfImage{}
+	for _, card := range cards {
+		if card.ImageUrl == "" {
+			continue
+		}
+		if _, ok := images[card.ImageUrl]; ok {
+			continue
+		}
+		images[card.ImageUrl] = nil
+		data, _, err := uc.downloadCardMedia(card.ImageUrl)
+		if err != nil {
+			logrus.Warnf("failed to download image of card %s: %v", card.Id, err)
+			continue
+		}
+		data, imageType, err := pdfImageData(data)
+		if err != nil {
+			logrus.Warnf("failed to decode image of card %s: %v", card.Id, err)
+			continue
+		}
+		name := fmt.Sprintf("image%d", len(images))
+		info := pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(data))
+		if pdf.Err() {
+			logrus.Warnf("failed to add image of card %s: %v", card.Id, pdf.Error())
+			pdf.ClearError()
+			continue
+		}
+		images[card.ImageUrl] = &pdfImage{name: name, width: info.Width(), height: info.Height()}
+	}
+	return images
+}
+
+// pdfImageData returns the image in a format the PDF writer supports,
+// JPEG is embedded as is and the other formats are converted to 8-bit PNG
+func pdfImageData(data []byte) ([]byte, string, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if format == "jpeg" {
+		return data, "JPG", nil
+	}
+	rgba := image.NewNRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	buf := &bytes.Buffer{}
+	err = png.Encode(buf, rgba)
+	if err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "PNG", nil
+}
+
+// writePdfCards prints every page of card fronts followed by a page with their backs,
+// the columns of the back page are mirrored so a sheet printed on both sides (flipped on the long edge)
+// has the back of every card behind its front
+func writePdfCards(pdf *fpdf.Fpdf, cards []*entity.CardForUser, images map[string]*pdfImage, grid [2]int) {
+	columns, rows := grid[0], grid[1]
+	pageWidth, pageHeight := pdf.GetPageSize()
+	cellWidth := (pageWidth - 2*pdfPageMargin) / float64(columns)
+	cellHeight := (pageHeight - 2*pdfPageMargin) / float64(rows)
+	cellSize := math.Min(cellWidth, cellHeight)
+	wordSize := math.Max(math.Min(cellSize*0.45, 36), 12)
+	backSize := math.Max(math.Min(cellSize*0.2, 16), 8)
+
+	perPage := columns * rows
+	for start := 0; start < len(cards); start += perPage {
+		pageCards := cards[start:int(math.Min(float64(start+perPage), float64(len(cards))))]
+
+		pdf.AddPage()
+		for i, card := range pageCards {
+			x := pdfPageMargin + float64(i%columns)*cellWidth
+			y := pdfPageMargin + float64(i/columns)*cellHeight
+			drawPdfCutLines(pdf, x, y, cellWidth, cellHeight)
+			drawPdfFront(pdf, card, images[card.ImageUrl], x, y, cellWidth, cellHeight, wordSize)
+		}
+
+		pdf.AddPage()
+		for i, card := range pageCards {
+			x := pdfPageMargin + float64(columns-1-i%columns)*cellWidth
+			y := pdfPageMargin + float64(i/columns)*cellHeight
+			drawPdfCutLines(pdf, x, y, cellWidth, cellHeight)
+			drawPdfBack(pdf, card, x, y, cellWidth, cellHeight, backSize)
+		}
+	}
+}
+
+func drawPdfCutLines(pdf *fpdf.Fpdf, x, y, width, height float64) {
+	pdf.SetDrawColor(180, 180, 180)
+	pdf.SetLineWidth(0.2)
+	pdf.SetDashPattern([]float64{2, 2}, 0)
+	pdf.Rect(x, y, width, height, "D")
+	pdf.SetDashPattern([]float64{}, 0)
+}
+
+func drawPdfFront(pdf *fpdf.Fpdf, card *entity.CardForUser, img *pdfImage, x, y, width, height, fontSize float64) {
+	x, y = x+pdfCardPadding, y+pdfCardPadding
+	width, height = width-2*pdfCardPadding, height-2*pdfCardPadding
+	blocks := []pdfBlock{{style: "B", scale: 1, text: card.Word}}
+	// The word takes at most a third of the card, the image fills the rest
+	_, textHeight := fitPdfBlocks(pdf, blocks, width, height/3, fontSize)
+	textHeight = math.Min(textHeight, height/3)
+	imageWidth, imageHeight := 0.0, 0.0
+	if img != nil {
+		imageWidth, imageHeight = fitPdfImage(img, width, height-textHeight-pdfImageGap)
+	}
+	if imageWidth == 0 || imageHeight == 0 {
+		drawPdfBlocks(pdf, blocks, x, y, width, height, fontSize, "C")
+		return
+	}
+	top := y + (height-textHeight-pdfImageGap-imageHeight)/2
+	drawPdfBlocks(pdf, blocks, x, top, width, textHeight, fontSize, "C")
+	pdf.ImageOptions(img.name, x+(width-imageWidth)/2, top+textHeight+pdfImageGap, imageWidth, imageHeight, false, fpdf.ImageOptions{}, 0, "")
+}
+
+func drawPdfBack(pdf *fpdf.Fpdf, card *entity.CardForUser, x, y, width, height, fontSize float64) {
+	drawPdfBlocks(
+		pdf,
+		pdfBackBlocks(card),
+		x+pdfCardPadding,
+		y+pdfCardPadding,
+		width-2*pdfCardPadding,
+		height-2*pdfCardPadding,
+		fontSize,
+		"C",
+	)
+}
+
+func pdfBackBlocks(card *entity.CardForUser) []pdfBlock {
+	blocks := []pdfBlock{{style: "", scale: 1, text: card.Definition}}
+	if card.Sentence != "" {
+		blocks = append(blocks, pdfBlock{style: "I", scale: 0.9, text: card.Sentence})
+	}
+	if card.Synonyms != "" {
+		blocks = append(blocks, pdfBlock{style: "", scale: 0.8, text: "Synonyms: " + card.Synonyms})
+	}
+	if card.Antonyms != "" {
+		blocks = append(blocks, pdfBlock{style: "", scale: 0.8, text: "Antonyms: " + card.Antonyms})
+	}
+	return blocks
+}
+
+// writePdfList prints the cards one below the other with a thumbnail of the image next to the text
+func writePdfList(pdf *fpdf.Fpdf, collection *entity.Collection, cards []*entity.CardForUser, images map[string]*pdfImage) {
+	pageWidth, pageHeight := pdf.GetPageSize()
+	width := pageWidth - 2*pdfPageMargin
+	bottom := pageHeight - pdfPageMargin
+
+	pdf.AddPage()
+	title := []pdfBlock{{style: "B", scale: 1, text: collection.Name}}
+	_, titleHeight := fitPdfBlocks(pdf, title, width, bottom, 18)
+	drawPdfBlocks(pdf, title, pdfPageMargin, pdfPageMargin, width, titleHeight, 18, "L")
+	y := pdfPageMargin + titleHeight + pdfListEntryGap
+
+	for _, card := range cards {
+		img := images[card.ImageUrl]
+		textX, textWidth := pdfPageMargin, width
+		if img != nil {
+			textX += pdfListImageSize + pdfImageGap
+			textWidth -= pdfListImageSize + pdfImageGap
+		}
+		blocks := append([]pdfBlock{{style: "B", scale: 1.2, text: card.Word}}, pdfBackBlocks(card)...)
+		_, textHeight := fitPdfBlocks(pdf, blocks, textWidth, bottom, 11)
+		entryHeight := textHeight
+		if img != nil {
+			entryHeight = math.Max(entryHeight, pdfListImageSize)
+		}
+		if y+entryHeight > bottom && y > pdfPageMargin {
+			pdf.AddPage()
+			y = pdfPageMargin
+		}
+
+		if img != nil {
+			imageWidth, imageHeight := fitPdfImage(img, pdfListImageSize, pdfListImageSize)
+			pdf.ImageOptions(img.name, pdfPageMargin+(pdfListImageSize-imageWidth)/2, y, imageWidth, imageHeight, false, fpdf.ImageOptions{}, 0, "")
+		}
+		drawPdfBlocks(pdf, blocks, textX, y, textWidth, math.Min(textHeight, bottom-y), 11, "L")
+		y += entryHeight + pdfListEntryGap/2
+		pdf.SetDrawColor(200, 200, 200)
+		pdf.SetLineWidth(0.2)
+		pdf.Line(pdfPageMargin, y, pdfPageMargin+width, y)
+		y += pdfListEntryGap / 2
+	}
+}
+
+// fitPdfBlocks wraps the blocks into the width and returns the largest font size, down to pdfMinFontSize,
+// at which they fit into the height together with the height they take at that size
+func fitPdfBlocks(pdf *fpdf.Fpdf, blocks []pdfBlock, width, height, fontSize float64) (float64, float64) {
+	for ; ; fontSize-- {
+		total := 0.0
+		for _, block := range blocks {
+			size := fontSize * block.scale
+			pdf.SetFont(pdfFontFamily, block.style, size)
+			total += float64(len(pdf.SplitText(pdfText(block.text), width))) * pdfLineHeight(size)
+		}
+		if total <= height || fontSize <= pdfMinFontSize {
+			return fontSize, total
+		}
+	}
+}
+
+// drawPdfBlocks writes the blocks vertically centered in the box, lines that do not fit are left out
+func drawPdfBlocks(pdf *fpdf.Fpdf, blocks []pdfBlock, x, y, width, height, fontSize float64, align string) {
+	fontSize, total := fitPdfBlocks(pdf, blocks, width, height, fontSize)
+	top := y
+	if total < height {
+		top += (height - total) / 2
+	}
+	pdf.SetTextColor(0, 0, 0)
+	for _, block := range blocks {
+		size := fontSize * block.scale
+		lineHeight := pdfLineHeight(size)
+		pdf.SetFont(pdfFontFamily, block.style, size)
+		for _, line := range pdf.SplitText(pdfText(block.text), width) {
+			if top+lineHeight > y+height+0.01 {
+				return
+			}
+			pdf.SetXY(x, top)
+			pdf.CellFormat(width, lineHeight, line, "", 0, align, false, 0, "")
+			top += lineHeight
+		}
+	}
+}
+
+// fitPdfImage scales the image to fit into the box keeping its aspect ratio
+func fitPdfImage(img *pdfImage, width, height float64) (float64, float64) {
+	if img.width <= 0 || img.height <= 0 || width <= 0 || height <= 0 {
+		return 0, 0
+	}
+	scale := math.Min(width/img.width, height/img.height)
+	return img.width * scale, img.height * scale
+}
+
+func pdfLineHeight(fontSize float64) float64 {
+	return fontSize * 25.4 / 72 * pdfLineHeightRate
+}
+
+// pdfText drops the characters the PDF fonts cannot measure: control characters other than
+// line breaks and characters outside of the Basic Multilingual Plane such as emoji
+func pdfText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		if r > 0xFFFF || (unicode.IsControl(r) && r != '\n') {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(text))
+}
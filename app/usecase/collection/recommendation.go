@@ -0,0 +1,123 @@
+package collection_usecase
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+const (
+	recommendationTopicWeight        = 3.0
+	recommendationCoEngagementWeight = 4.0
+	recommendationPopularityWeight   = 1.0
+
+	engagementStudiedWeight = 1.0
+	engagementLikedWeight   = 2.0
+	engagementStarredWeight = 2.0
+)
+
+// recommendCollections scores the collections by topic overlap with the collections the user studies,
+// likes and stars, by co-engagement of other users and by popularity, the repository scores and pages them.
+// Collections the user has already mastered are never recommended.
+// Sorting by rating orders the scored collections by their average review rating instead.
+func (uc *usecase) recommendCollections(userId uuid.UUID, limit, offset int, sortBy entity.CollectionSort, filter entity.SearchFilter) ([]*entity.CollectionRecommendation, error) {
+	engagements, err := uc.collectionRepo.GetUserCollectionEngagements(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	topicWeights := map[string]float64{}
+	maxTopicWeight := 0.0
+	engagedById := map[uuid.UUID]*entity.CollectionEngagement{}
+	sourceIds := []uuid.UUID{}
+	for _, engagement := range engagements {
+		engagedById[engagement.CollectionId] = engagement
+		weight := 0.0
+		if engagement.Studied {
+			weight += engagementStudiedWeight
+		}
+		if engagement.Liked {
+			weight += engagementLikedWeight
+		}
+		if engagement.Starred {
+			weight += engagementStarredWeight
+		}
+		for _, topic := range uniqueTopics(engagement.Topics) {
+			topicWeights[topic] += weight
+			maxTopicWeight = math.Max(maxTopicWeight, topicWeights[topic])
+		}
+		if engagement.Liked || engagement.Starred {
+			sourceIds = append(sourceIds, engagement.CollectionId)
+		}
+	}
+	if maxTopicWeight > 0 {
+		for topic := range topicWeights {
+			topicWeights[topic] /= maxTopicWeight
+		}
+	}
+
+	candidates, err := uc.collectionRepo.GetRecommendationCandidates(userId, entity.RecommendationQuery{
+		TopicWeights:       topicWeights,
+		SourceIds:          sourceIds,
+		TopicWeight:        recommendationTopicWeight,
+		CoEngagementWeight: recommendationCoEngagementWeight,
+		PopularityWeight:   recommendationPopularityWeight,
+	}, limit, offset, sortBy, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := []*entity.CollectionRecommendation{}
+	for _, candidate := range candidates {
+		bestTopic := ""
+		bestTopicWeight := 0.0
+		for _, topic := range uniqueTopics(candidate.Collection.Topics) {
+			if topicWeights[topic] > bestTopicWeight {
+				bestTopicWeight = topicWeights[topic]
+				bestTopic = topic
+			}
+		}
+		topicContribution := recommendationTopicWeight * candidate.TopicScore
+		coContribution := recommendationCoEngagementWeight * candidate.CoEngagementScore
+
+		reason := "Popular with other learners"
+		var source *entity.CollectionEngagement
+		if candidate.BestSourceId != nil {
+			source = engagedById[*candidate.BestSourceId]
+		}
+		if source != nil && coContribution > 0 && coContribution >= topicContribution {
+			if source.Liked {
+				reason = fmt.Sprintf("Because you liked %s", source.Name)
+			} else {
+				reason = fmt.Sprintf("Because you starred %s", source.Name)
+			}
+		} else if topicContribution > 0 {
+			reason = fmt.Sprintf("Because you study %s", bestTopic)
+		}
+
+		recommendations = append(recommendations, &entity.CollectionRecommendation{
+			Collection:    candidate.Collection,
+			Score:         candidate.Score,
+			AverageRating: candidate.AverageRating,
+			Reason:        reason,
+		})
+	}
+	return recommendations, nil
+}
+
+func uniqueTopics(topics []string) []string {
+	seen := map[string]bool{}
+	res := []string{}
+	for _, topic := range topics {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if topic == "" || seen[topic] {
+			continue
+		}
+		seen[topic] = true
+		res = append(res, topic)
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+package collection_usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ReviewCollection creates the user's review of a collection or replaces the existing one,
+// authors cannot review their own collections
+func (uc *usecase) ReviewCollection(id, userId uuid.UUID, review entity.CollectionReviewRequest) (*entity.CollectionReview, error) {
+	if review.Rating < entity.CollectionReviewMinRating || review.Rating > entity.CollectionReviewMaxRating {
+		return nil, ErrInvalidRating
+	}
+	collection, err := uc.getCollection(id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.AuthorId == userId {
+		return nil, ErrForbiddenSelfRequest
+	}
+
+	res, err := uc.collectionRepo.UpsertCollectionReview(id, userId, review.Rating, strings.TrimSpace(review.Text))
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return res, nil
+}
+
+func (uc *usecase) DeleteCollectionReview(id, userId uuid.UUID) error {
+	err := uc.collectionRepo.DeleteCollectionReview(id, userId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCollectionReviewNotFound) {
+			return ErrReviewNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+// ReplyToCollectionReview stores the author's reply to a review, only the collection author can reply
+func (uc *usecase) ReplyToCollectionReview(reviewId, userId uuid.UUID, reply string) error {
+	review, err := uc.collectionRepo.GetCollectionReviewById(reviewId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrCollectionReviewNotFound) {
+			return ErrReviewNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	collection, err := uc.getCollection(review.CollectionId)
+	if err != nil {
+		return err
+	}
+	if collection.AuthorId != userId {
+		return ErrUnauthorized
+	}
+
+	err = uc.collectionRepo.ReplyToCollectionReview(reviewId, strings.TrimSpace(reply))
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+func (uc *usecase) GetCollectionReviews(id uuid.UUID, page, size int) (*entity.CollectionReviewPagination, error) {
+	limit := size
+	offset := (page - 1) * size
+
+	reviews, total, err := uc.collectionRepo.GetCollectionReviews(id, limit, offset)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	collectionMetrics, err := uc.collectionRepo.GetCollectionMetrics(id)
+	if err != nil && !errors.Is(err, repositoryIntf.ErrCollectionMetricsNotFound) {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+
+	return &entity.CollectionReviewPagination{
+		Reviews:       reviews,
+		AverageRating: collectionMetrics.AverageRating,
+		Ratings:       collectionMetrics.Ratings,
+		Page:          page,
+		Size:          size,
+		Total:         total,
+	}, nil
+}
+
+func (uc *usecase) getCollection(id uuid.UUID) (*entity.Collection, error) {
+	collection, err := uc.collectionRepo.GetCollection(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, repositoryIntf.ErrCollectionNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return collection, nil
+}
@@ -0,0 +1,117 @@
+package collection_usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/flash-cards-vocab/backend/pkg/audio"
+	"github.com/flash-cards-vocab/backend/pkg/media"
+	"github.com/google/uuid"
+)
+
+const cardImagesLocation = "card_images"
+
+// uploadCardImage stores a card image in the same location as the images uploaded with the card usecase
+func (uc *usecase) uploadCardImage(file io.Reader, filename string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*50)
+	defer cancel()
+
+	filenameToUpload := cardImagesLocation + "/" + strings.ReplaceAll(filename, " ", "+") + "--" + uuid.NewString()
+	fileURL := "https://storage.googleapis.com/" + uc.bucketName + "/" + uc.envPrefix + "/" + filenameToUpload
+	wc := uc.gcsClient.Bucket(uc.bucketName).Object(uc.envPrefix + "/" + filenameToUpload).NewWriter(ctx)
+
+	if _, err := io.Copy(wc, file); err != nil {
+		wc.Close()
+		return "", fmt.Errorf("%w: %v", ErrUnexpected, err)
+	}
+	if err := wc.Close(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnexpected, err)
+	}
+	return fileURL, nil
+}
+
+// downloadCardMedia returns the content of a card image or audio and a filename with an extension matching its content
+func (uc *usecase) downloadCardMedia(mediaUrl string) ([]byte, string, error) {
+	return media.Download(uc.gcsClient, uc.bucketName, mediaUrl)
+}
+
+// storeImageFromUrl copies an image from an external URL into the storage, images already in the storage are kept
+func (uc *usecase) storeImageFromUrl(imageUrl, word string) (string, error) {
+	if strings.HasPrefix(imageUrl, "https://storage.googleapis.com/"+uc.bucketName+"/") {
+		return imageUrl, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*50)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, imageUrl, nil)
+	if err != nil {
+		return "", err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("received %d response code", response.StatusCode)
+	}
+	return uc.uploadCardImage(response.Body, word)
+}
+
+// storeAudioFromUrl copies the audio of a card from an external URL into the storage after validating it,
+// audio already in the storage is kept
+func (uc *usecase) storeAudioFromUrl(audioUrl, word string) (string, error) {
+	if strings.HasPrefix(audioUrl, "https://storage.googleapis.com/"+uc.bucketName+"/") {
+		return audioUrl, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*50)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, audioUrl, nil)
+	if err != nil {
+		return "", err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("received %d response code", response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, audio.MaxSize+1))
+	if err != nil {
+		return "", err
+	}
+	return uc.uploadCardAudio(data, word)
+}
+
+// uploadCardAudio validates the audio and stores it in the same location as the audio uploaded with the card usecase
+func (uc *usecase) uploadCardAudio(data []byte, name string) (string, error) {
+	info, err := audio.Check(data)
+	if err != nil {
+		return "", err
+	}
+	return media.UploadCardAudio(uc.gcsClient, uc.bucketName, uc.envPrefix, data, name, info.Format)
+}
+
+// storeCardsAudio copies the external audio of the cards into the storage, a card with invalid audio
+// fails the whole request as the client sent the audio explicitly
+func (uc *usecase) storeCardsAudio(cards []*entity.Card) error {
+	var err error
+	for _, card := range cards {
+		if card.AudioUrl == "" {
+			continue
+		}
+		card.AudioUrl, err = uc.storeAudioFromUrl(card.AudioUrl, card.Word)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidAudio, card.Word, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,429 @@
+package collection_usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/xuri/excelize/v2"
+)
+
+// cardWordMaxLen is the length of the card word column
+const cardWordMaxLen = 150
+
+// Header names recognized for every card field when the client does not send a column mapping
+var importColumnAliases = map[entity.ImportColumn][]string{
+	entity.ImportColumn_Word:           {"word", "term", "front", "expression", "vocabulary"},
+	entity.ImportColumn_Definition:     {"definition", "meaning", "back", "translation"},
+	entity.ImportColumn_Sentence:       {"sentence", "example", "example_sentence", "context"},
+	entity.ImportColumn_ImageUrl:       {"image_url", "image", "picture", "image_link"},
+	entity.ImportColumn_Antonyms:       {"antonyms", "antonym"},
+	entity.ImportColumn_Synonyms:       {"synonyms", "synonym"},
+	entity.ImportColumn_Level:          {"level", "cefr", "difficulty"},
+	entity.ImportColumn_SourceLanguage: {"source_language", "source"},
+	entity.ImportColumn_TargetLanguage: {"target_language", "target"},
+	entity.ImportColumn_PartOfSpeech:   {"part_of_speech", "pos", "word_class"},
+	entity.ImportColumn_Pronunciation:  {"pronunciation", "ipa", "transcription"},
+	entity.ImportColumn_Gender:         {"gender"},
+	entity.ImportColumn_Plural:         {"plural"},
+	entity.ImportColumn_AudioUrl:       {"audio_url", "audio", "sound"},
+}
+
+func (uc *usecase) ImportTable(
+	userId uuid.UUID,
+	collection entity.Collection,
+	file io.Reader,
+	filename string,
+	options entity.TableImportOptions,
+) (*entity.TableImportResult, error) {
+	rows, err := readTableRows(file, filename)
+	if err != nil {
+		return nil, err
+	}
+	// The collection languages and level are validated before the rows so a dry run reports them too
+	err = setCollectionLanguages(&collection, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = setCollectionLevels(&collection, nil)
+	if err != nil {
+		return nil, err
+	}
+	options.Duplicates, err = duplicatePolicy(options.Duplicates)
+	if err != nil {
+		return nil, err
+	}
+	return uc.importTableRows(userId, collection, rows, options)
+}
+
+func (uc *usecase) importTableRows(
+	userId uuid.UUID,
+	collection entity.Collection,
+	rows [][]string,
+	options entity.TableImportOptions,
+) (*entity.TableImportResult, error) {
+	columns, hasHeader, err := resolveImportColumns(rows, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.TableImportResult{
+		DryRun:  options.DryRun,
+		Columns: columns,
+		Rows:    []*entity.ImportRowResult{},
+	}
+	firstRow := 0
+	if hasHeader {
+		firstRow = 1
+	}
+	// Rows with the same word are allowed but reported
+	wordRows := map[string]int{}
+	for i := firstRow; i < len(rows); i++ {
+		if isBlankRow(rows[i]) {
+			continue
+		}
+		rowResult := validateImportRow(i+1, rows[i], columns)
+		if rowResult.Card != nil {
+			key := strings.ToLower(rowResult.Card.Word)
+			if row, ok := wordRows[key]; ok {
+				rowResult.Warnings = append(rowResult.Warnings, fmt.Sprintf("word is a duplicate of row %d", row))
+			} else {
+				wordRows[key] = rowResult.Row
+			}
+		}
+		if len(rowResult.Errors) == 0 {
+			result.ValidRows++
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+	if options.DryRun {
+		return result, nil
+	}
+	if result.ValidRows == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	report, err := uc.createImportedCollection(userId, collection, result.Rows, options.Duplicates)
+	if err != nil {
+		return nil, err
+	}
+	result.Report = report
+	return result, nil
+}
+
+// createImportedCollection creates the collection from the rows without errors, the other rows are reported as skipped
+func (uc *usecase) createImportedCollection(
+	userId uuid.UUID,
+	collection entity.Collection,
+	rows []*entity.ImportRowResult,
+	duplicates entity.DuplicatePolicy,
+) (*entity.ImportReport, error) {
+	report := &entity.ImportReport{Skipped: []*entity.ImportSkip{}}
+	cards := []*entity.Card{}
+	cardRows := map[*entity.Card]*entity.ImportRowResult{}
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			report.Skipped = append(report.Skipped, &entity.ImportSkip{
+				Source: fmt.Sprintf("row %d", row.Row),
+				Reason: strings.Join(row.Errors, "; "),
+			})
+			continue
+		}
+		cards = append(cards, row.Card)
+		cardRows[row.Card] = row
+	}
+	// Duplicates are resolved first so no image is downloaded for a skipped or merged card
+	resolved, err := uc.resolveDuplicates(userId, cards, duplicates)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	for _, card := range resolved.Cards {
+		row := cardRows[card]
+		if card.ImageUrl != "" {
+			imageUrl, err := uc.storeImageFromUrl(card.ImageUrl, card.Word)
+			if err != nil {
+				row.Warnings = append(row.Warnings, "image could not be downloaded, the card is created without it")
+				imageUrl = ""
+			}
+			card.ImageUrl = imageUrl
+		}
+		if card.AudioUrl != "" {
+			audioUrl, err := uc.storeAudioFromUrl(card.AudioUrl, card.Word)
+			if err != nil {
+				row.Warnings = append(row.Warnings, "audio could not be stored, the card is created without it: "+err.Error())
+				audioUrl = ""
+			}
+			card.AudioUrl = audioUrl
+		}
+		card.Id = uuid.New()
+		card.AuthorId = userId
+	}
+
+	collection.AuthorId = userId
+	err = setCollectionLanguages(&collection, resolved.Cards)
+	if err != nil {
+		return nil, err
+	}
+	err = setCollectionLevels(&collection, resolved.Cards)
+	if err != nil {
+		return nil, err
+	}
+	created, err := uc.collectionRepo.CreateCollectionWithCards(collection, resolved.Cards, resolved.ExistingCardIds)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	report.CollectionId = created.Id
+	report.Name = created.Name
+	report.CardsAmount = resolved.amount()
+	report.Duplicates = resolved.Duplicates
+	return report, nil
+}
+
+// readTableRows reads the rows of a CSV, TSV or the first sheet of an XLSX file
+func readTableRows(file io.Reader, filename string) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xlsx", ".xlsm":
+		f, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		defer f.Close()
+		rows, err := f.GetRows(f.GetSheetName(0))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		return rows, nil
+	case ".csv":
+		return readDelimitedRows(file, 0)
+	case ".tsv", ".tab", ".txt":
+		return readDelimitedRows(file, '\t')
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, "file must be a .csv, .tsv or .xlsx file")
+	}
+}
+
+// tableSheet is a sheet of an uploaded file, CSV and TSV files have a single sheet without a name
+type tableSheet struct {
+	Name string
+	Rows [][]string
+}
+
+// readTableSheets reads the rows of every sheet of an XLSX file, or the rows of a CSV or TSV file
+func readTableSheets(file io.Reader, filename string) ([]*tableSheet, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xlsx", ".xlsm":
+		f, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		defer f.Close()
+		sheets := []*tableSheet{}
+		for _, name := range f.GetSheetList() {
+			rows, err := f.GetRows(name)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+			}
+			sheets = append(sheets, &tableSheet{Name: name, Rows: rows})
+		}
+		return sheets, nil
+	default:
+		rows, err := readTableRows(file, filename)
+		if err != nil {
+			return nil, err
+		}
+		return []*tableSheet{{Rows: rows}}, nil
+	}
+}
+
+// readDelimitedRows reads delimiter separated rows, a zero delimiter is detected from the first line
+func readDelimitedRows(file io.Reader, delimiter rune) ([][]string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if delimiter == 0 {
+		firstLine := string(data)
+		if i := strings.IndexByte(firstLine, '\n'); i >= 0 {
+			firstLine = firstLine[:i]
+		}
+		// Spreadsheets with a comma as the decimal separator export CSV with semicolons
+		delimiter = ','
+		if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+			delimiter = ';'
+		}
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	return rows, nil
+}
+
+// resolveImportColumns returns the column index of every mapped card field and whether the first row is a header
+func resolveImportColumns(rows [][]string, options entity.TableImportOptions) (map[entity.ImportColumn]int, bool, error) {
+	header := []string{}
+	if len(rows) > 0 {
+		for _, cell := range rows[0] {
+			header = append(header, normalizeHeader(cell))
+		}
+	}
+	detected := map[entity.ImportColumn]int{}
+	for i, name := range header {
+		for column, aliases := range importColumnAliases {
+			if _, ok := detected[column]; ok {
+				continue
+			}
+			for _, alias := range aliases {
+				if name == alias {
+					detected[column] = i
+				}
+			}
+		}
+	}
+	hasHeader := len(detected) > 0
+	if options.HasHeader != nil {
+		hasHeader = *options.HasHeader
+	}
+
+	columns := map[entity.ImportColumn]int{}
+	if len(options.Mapping) > 0 {
+		for column, value := range options.Mapping {
+			if !column.IsValid() {
+				return nil, false, fmt.Errorf("%w: unknown field %q", ErrInvalidColumnMapping, column)
+			}
+			if index, err := strconv.Atoi(value); err == nil && index >= 0 {
+				columns[column] = index
+				continue
+			}
+			if !hasHeader {
+				return nil, false, fmt.Errorf("%w: field %q is mapped to a header name but the file has no header", ErrInvalidColumnMapping, column)
+			}
+			index := -1
+			for i, name := range header {
+				if name == normalizeHeader(value) {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				return nil, false, fmt.Errorf("%w: column %q not found", ErrInvalidColumnMapping, value)
+			}
+			columns[column] = index
+		}
+	} else if hasHeader {
+		columns = detected
+	} else {
+		for i, column := range entity.ImportColumns {
+			columns[column] = i
+		}
+	}
+
+	if _, ok := columns[entity.ImportColumn_Word]; !ok {
+		return nil, false, fmt.Errorf("%w: word column is not mapped", ErrInvalidColumnMapping)
+	}
+	if _, ok := columns[entity.ImportColumn_Definition]; !ok {
+		return nil, false, fmt.Errorf("%w: definition column is not mapped", ErrInvalidColumnMapping)
+	}
+	return columns, hasHeader, nil
+}
+
+func validateImportRow(rowNumber int, cells []string, columns map[entity.ImportColumn]int) *entity.ImportRowResult {
+	result := &entity.ImportRowResult{
+		Row:      rowNumber,
+		Errors:   []string{},
+		Warnings: []string{},
+	}
+	value := func(column entity.ImportColumn) string {
+		index, ok := columns[column]
+		if !ok || index >= len(cells) {
+			return ""
+		}
+		return strings.TrimSpace(cells[index])
+	}
+
+	card := &entity.Card{
+		Word:       value(entity.ImportColumn_Word),
+		Definition: value(entity.ImportColumn_Definition),
+		Sentence:   value(entity.ImportColumn_Sentence),
+		ImageUrl:   value(entity.ImportColumn_ImageUrl),
+		AudioUrl:   value(entity.ImportColumn_AudioUrl),
+		Antonyms:   value(entity.ImportColumn_Antonyms),
+		Synonyms:   value(entity.ImportColumn_Synonyms),
+	}
+	if card.Word == "" {
+		result.Errors = append(result.Errors, "word is empty")
+	} else if utf8.RuneCountInString(card.Word) > cardWordMaxLen {
+		result.Errors = append(result.Errors, fmt.Sprintf("word is longer than %d characters", cardWordMaxLen))
+	}
+	if card.Definition == "" {
+		result.Errors = append(result.Errors, "definition is empty")
+	}
+	if card.Sentence == "" {
+		result.Warnings = append(result.Warnings, "sentence is empty")
+	}
+	if card.ImageUrl == "" {
+		result.Warnings = append(result.Warnings, "image_url is empty")
+	} else if !strings.HasPrefix(card.ImageUrl, "http://") && !strings.HasPrefix(card.ImageUrl, "https://") {
+		result.Errors = append(result.Errors, "image_url must be an http(s) URL")
+	}
+	if card.AudioUrl != "" && !strings.HasPrefix(card.AudioUrl, "http://") && !strings.HasPrefix(card.AudioUrl, "https://") {
+		result.Errors = append(result.Errors, "audio_url must be an http(s) URL")
+	}
+
+	var err error
+	card.Level, err = entity.NormalizeLevel(value(entity.ImportColumn_Level))
+	if err != nil {
+		result.Errors = append(result.Errors, "level: "+ErrInvalidLevel.Error())
+	}
+	card.SourceLanguage, err = entity.NormalizeLanguageTag(value(entity.ImportColumn_SourceLanguage))
+	if err != nil {
+		result.Errors = append(result.Errors, "source_language: "+ErrInvalidLanguage.Error())
+	}
+	card.TargetLanguage, err = entity.NormalizeLanguageTag(value(entity.ImportColumn_TargetLanguage))
+	if err != nil {
+		result.Errors = append(result.Errors, "target_language: "+ErrInvalidLanguage.Error())
+	}
+	card.Senses = entity.NewCardSenses(value(entity.ImportColumn_PartOfSpeech), card.Definition, card.Sentence)
+	card.Pronunciation = value(entity.ImportColumn_Pronunciation)
+	card.Gender = value(entity.ImportColumn_Gender)
+	card.Plural = value(entity.ImportColumn_Plural)
+	err = entity.NormalizeCardBody(card)
+	if errors.Is(err, entity.ErrInvalidPartOfSpeech) {
+		result.Errors = append(result.Errors, "part_of_speech: "+ErrInvalidPartOfSpeech.Error())
+	} else if err != nil {
+		result.Errors = append(result.Errors, ErrInvalidCardBody.Error())
+	}
+
+	result.Card = card
+	return result
+}
+
+func normalizeHeader(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
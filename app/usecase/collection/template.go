@@ -0,0 +1,346 @@
+package collection_usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/xuri/excelize/v2"
+)
+
+// Layout of the collection upload template, rows and columns are zero-based.
+// The collection fields are in column B, the cards start at row 8 below the header row.
+const (
+	templateCollectionIdRow    = 1
+	templateNameRow            = 2
+	templateTopicsRow          = 3
+	templateSourceLanguageRow  = 4
+	templateTargetLanguageRow  = 5
+	templateHeaderRow          = 6
+	templateFirstCardRow       = 7
+	templateCardIdColumn       = 7
+	templateSheetName          = "Collection"
+	templateMinimumCardColumns = 4
+	templateValueColumn        = 1
+)
+
+// The columns after the card id hold the part of speech of the first sense and the pronunciation and noun forms
+const (
+	templatePartOfSpeechColumn  = 8
+	templatePronunciationColumn = 9
+	templateGenderColumn        = 10
+	templatePluralColumn        = 11
+	templateAudioUrlColumn      = 12
+)
+
+var templateLabels = map[int]string{
+	templateCollectionIdRow:   "Collection id",
+	templateNameRow:           "Name",
+	templateTopicsRow:         "Topics",
+	templateSourceLanguageRow: "Source language",
+	templateTargetLanguageRow: "Target language",
+}
+
+var templateHeader = []string{"Word", "Definition", "Sentence", "Image URL", "Antonyms", "Synonyms", "Level", "Card id", "Part of speech", "Pronunciation", "Gender", "Plural", "Audio URL"}
+
+// collectionTemplateRows lays out the collection and its cards the way parseCollectionTemplate reads them
+func collectionTemplateRows(collection *entity.Collection, cards []*entity.CardForUser) [][]string {
+	rows := make([][]string, templateFirstCardRow, templateFirstCardRow+len(cards))
+	rows[0] = []string{"Flash cards collection"}
+	values := map[int]string{
+		templateCollectionIdRow:   collection.Id.String(),
+		templateNameRow:           collection.Name,
+		templateTopicsRow:         strings.Join(collection.Topics, ";"),
+		templateSourceLanguageRow: collection.SourceLanguage,
+		templateTargetLanguageRow: collection.TargetLanguage,
+	}
+	for row, label := range templateLabels {
+		rows[row] = []string{label, values[row]}
+	}
+	rows[templateHeaderRow] = templateHeader
+	for _, card := range cards {
+		rows = append(rows, []string{
+			card.Word,
+			card.Definition,
+			card.Sentence,
+			card.ImageUrl,
+			card.Antonyms,
+			card.Synonyms,
+			card.Level,
+			card.Id.String(),
+			string(firstPartOfSpeech(card.Senses)),
+			card.Pronunciation,
+			card.Gender,
+			card.Plural,
+			card.AudioUrl,
+		})
+	}
+	return rows
+}
+
+// parseCollectionTemplate reads the collection and its cards from the template rows,
+// the collection id and the card ids are only set in files exported from an existing collection
+func parseCollectionTemplate(rows [][]string) (entity.Collection, []*entity.Card) {
+	cell := func(row, column int) string {
+		if row >= len(rows) || column >= len(rows[row]) {
+			return ""
+		}
+		return strings.TrimSpace(rows[row][column])
+	}
+
+	collection := entity.Collection{
+		Name:           cell(templateNameRow, templateValueColumn),
+		Topics:         strings.Split(cell(templateTopicsRow, templateValueColumn), ";"),
+		SourceLanguage: cell(templateSourceLanguageRow, templateValueColumn),
+		TargetLanguage: cell(templateTargetLanguageRow, templateValueColumn),
+	}
+	if id, err := uuid.Parse(cell(templateCollectionIdRow, templateValueColumn)); err == nil {
+		collection.Id = id
+	}
+
+	cards := []*entity.Card{}
+	for row := templateFirstCardRow; row < len(rows); row++ {
+		// Skip row that does not contain one of mandatory fields
+		if len(rows[row]) < templateMinimumCardColumns || cell(row, 0) == "" {
+			continue
+		}
+		card := &entity.Card{
+			Word:       cell(row, 0),
+			Definition: cell(row, 1),
+			Sentence:   cell(row, 2),
+			ImageUrl:   cell(row, 3),
+			Antonyms:   cell(row, 4),
+			Synonyms:   cell(row, 5),
+			Level:      cell(row, 6),
+		}
+		if id, err := uuid.Parse(cell(row, templateCardIdColumn)); err == nil {
+			card.Id = id
+		}
+		card.Senses = entity.NewCardSenses(cell(row, templatePartOfSpeechColumn), card.Definition, card.Sentence)
+		card.Pronunciation = cell(row, templatePronunciationColumn)
+		card.Gender = cell(row, templateGenderColumn)
+		card.Plural = cell(row, templatePluralColumn)
+		card.AudioUrl = cell(row, templateAudioUrlColumn)
+		cards = append(cards, card)
+	}
+	return collection, cards
+}
+
+// templateSheet is a collection read from a sheet of the uploaded file
+type templateSheet struct {
+	Name       string
+	Collection entity.Collection
+	Cards      []*entity.Card
+}
+
+// parseTemplateSheets reads a collection from every sheet of the file, blank sheets are skipped
+func parseTemplateSheets(sheets []*tableSheet) ([]*templateSheet, error) {
+	resp := []*templateSheet{}
+	for _, sheet := range sheets {
+		collection, cards := parseCollectionTemplate(sheet.Rows)
+		if collection.Name == "" && len(cards) == 0 {
+			continue
+		}
+		resp = append(resp, &templateSheet{Name: sheet.Name, Collection: collection, Cards: cards})
+	}
+	if len(resp) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, "file does not contain a collection")
+	}
+	return resp, nil
+}
+
+// sheetError tells which sheet of a workbook an error comes from
+func sheetError(sheet string, err error) error {
+	if sheet == "" {
+		return err
+	}
+	return fmt.Errorf("sheet %s: %w", sheet, err)
+}
+
+func exportXlsx(rows [][]string) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	f.SetSheetName(sheet, templateSheetName)
+	for i, row := range rows {
+		cells := make([]interface{}, len(row))
+		for j, value := range row {
+			cells[j] = value
+		}
+		cellName, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return nil, err
+		}
+		err = f.SetSheetRow(templateSheetName, cellName, &cells)
+		if err != nil {
+			return nil, err
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func exportCsv(rows [][]string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	err := writer.WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// updateCollectionFromTemplate applies an edited template to the collection it was exported from:
+// cards are matched by id, cards missing in the file are removed from the collection
+func (uc *usecase) updateCollectionFromTemplate(
+	userId uuid.UUID,
+	collection *entity.Collection,
+	updated entity.Collection,
+	cards []*entity.Card,
+	progress *importProgress,
+) (*entity.CreateMultipleCollectionResponse, error) {
+	total, err := uc.collectionRepo.GetTotalCardsInCollection(collection.Id)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	existing, err := uc.collectionRepo.GetCollectionCards(collection.Id, userId, total, 0)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	existingCards := map[uuid.UUID]*entity.CardForUser{}
+	for _, card := range existing.CardForUser {
+		existingCards[card.Id] = card
+	}
+
+	cardUpdates := []*entity.CardUpdate{}
+	kept := map[uuid.UUID]bool{}
+	for _, card := range cards {
+		existingCard, ok := existingCards[card.Id]
+		if ok && kept[card.Id] {
+			// The same card id twice in the file, the second row becomes a new card
+			ok = false
+		}
+		if ok && sameTemplateCard(existingCard, card) {
+			kept[card.Id] = true
+			err = progress.row(false, "")
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		slow, problem := false, ""
+		if card.ImageUrl != "" && (!ok || card.ImageUrl != existingCard.ImageUrl) {
+			slow = true
+			card.ImageUrl, err = uc.storeImageFromUrl(card.ImageUrl, card.Word)
+			if err != nil {
+				problem = fmt.Sprintf("%s: image could not be downloaded", card.Word)
+				card.ImageUrl = ""
+				if ok {
+					card.ImageUrl = existingCard.ImageUrl
+				}
+			}
+		}
+		if card.AudioUrl != "" && (!ok || card.AudioUrl != existingCard.AudioUrl) {
+			slow = true
+			card.AudioUrl, err = uc.storeAudioFromUrl(card.AudioUrl, card.Word)
+			if err != nil {
+				problem = joinProblems(problem, fmt.Sprintf("%s: audio could not be downloaded", card.Word))
+				card.AudioUrl = ""
+				if ok {
+					card.AudioUrl = existingCard.AudioUrl
+				}
+			}
+		}
+		err = progress.row(slow, problem)
+		if err != nil {
+			return nil, err
+		}
+		cardUpdate := &entity.CardUpdate{
+			Word:          card.Word,
+			ImageUrl:      card.ImageUrl,
+			AudioUrl:      card.AudioUrl,
+			Definition:    card.Definition,
+			Sentence:      card.Sentence,
+			Antonyms:      card.Antonyms,
+			Synonyms:      card.Synonyms,
+			Senses:        card.Senses,
+			Pronunciation: card.Pronunciation,
+			Gender:        card.Gender,
+			Plural:        card.Plural,
+			Level:         card.Level,
+			Action:        entity.CardUpdateType_Create,
+		}
+		if ok {
+			kept[card.Id] = true
+			cardUpdate.Id = card.Id
+			cardUpdate.Action = entity.CardUpdateType_Update
+			// The template only holds the summary of the senses, they are kept while it is unchanged
+			if sameTemplateSummary(existingCard, card) {
+				cardUpdate.Senses = nil
+			}
+		}
+		cardUpdates = append(cardUpdates, cardUpdate)
+	}
+	for _, card := range existing.CardForUser {
+		if !kept[card.Id] {
+			cardUpdates = append(cardUpdates, &entity.CardUpdate{
+				Id:     card.Id,
+				Action: entity.CardUpdateType_Remove,
+			})
+		}
+	}
+
+	// The job may have been cancelled during the last rows
+	err = progress.save()
+	if err != nil {
+		return nil, err
+	}
+	err = uc.UpdateCollection(userId, &entity.UpdateCollectionRequest{
+		Id:             collection.Id,
+		Name:           updated.Name,
+		Topics:         updated.Topics,
+		SourceLanguage: updated.SourceLanguage,
+		TargetLanguage: updated.TargetLanguage,
+		Cards:          cardUpdates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entity.CreateMultipleCollectionResponse{
+		Name:        updated.Name,
+		CardsAmount: uint32(len(cards)),
+	}, nil
+}
+
+func sameTemplateCard(existing *entity.CardForUser, card *entity.Card) bool {
+	return existing.Word == card.Word &&
+		existing.ImageUrl == card.ImageUrl &&
+		existing.AudioUrl == card.AudioUrl &&
+		existing.Antonyms == card.Antonyms &&
+		existing.Synonyms == card.Synonyms &&
+		existing.Pronunciation == card.Pronunciation &&
+		strings.EqualFold(existing.Gender, card.Gender) &&
+		existing.Plural == card.Plural &&
+		strings.EqualFold(existing.Level, card.Level) &&
+		sameTemplateSummary(existing, card)
+}
+
+// sameTemplateSummary tells whether the row still has the definition, sentence and part of speech
+// the template was exported with
+func sameTemplateSummary(existing *entity.CardForUser, card *entity.Card) bool {
+	partOfSpeech, err := entity.NormalizePartOfSpeech(string(firstPartOfSpeech(card.Senses)))
+	return err == nil &&
+		existing.Definition == card.Definition &&
+		existing.Sentence == card.Sentence &&
+		firstPartOfSpeech(existing.Senses) == partOfSpeech
+}
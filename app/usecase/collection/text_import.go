@@ -0,0 +1,194 @@
+package collection_usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const textImportMaxCards = 2000
+
+func (uc *usecase) ImportText(userId uuid.UUID, request entity.TextImportRequest) (*entity.TextImportResult, error) {
+	termSeparator, ok := request.TermSeparator.Value(request.CustomTermSeparator)
+	if !ok {
+		return nil, ErrInvalidSeparator
+	}
+	rowSeparator, ok := request.RowSeparator.Value(request.CustomRowSeparator)
+	if !ok || rowSeparator == termSeparator {
+		return nil, ErrInvalidSeparator
+	}
+	duplicates, err := duplicatePolicy(request.Duplicates)
+	if err != nil {
+		return nil, err
+	}
+
+	result := parseTextCards(request.Text, termSeparator, rowSeparator)
+	if len(result.Cards) > textImportMaxCards {
+		return nil, ErrTooManyCards
+	}
+	result.Preview = request.Preview
+	if request.Preview {
+		return result, nil
+	}
+	if len(result.Cards) == 0 {
+		return nil, ErrEmptyImport
+	}
+	for _, card := range result.Cards {
+		card.AuthorId = userId
+	}
+
+	if request.CollectionId != nil {
+		report, err := uc.appendImportedCards(userId, *request.CollectionId, result.Cards, duplicates)
+		if err != nil {
+			return nil, err
+		}
+		report.Skipped = result.Skipped
+		result.Report = report
+		return result, nil
+	}
+
+	collection := entity.Collection{
+		Name:           strings.TrimSpace(request.Name),
+		Topics:         request.Topics,
+		AuthorId:       userId,
+		SourceLanguage: request.SourceLanguage,
+		TargetLanguage: request.TargetLanguage,
+		Level:          request.Level,
+	}
+	if collection.Name == "" {
+		return nil, ErrEmptyCollectionName
+	}
+	err = setCollectionLanguages(&collection, result.Cards)
+	if err != nil {
+		return nil, err
+	}
+	err = setCollectionLevels(&collection, result.Cards)
+	if err != nil {
+		return nil, err
+	}
+	err = normalizeCardBodies(result.Cards)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := uc.resolveDuplicates(userId, result.Cards, duplicates)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	created, err := uc.collectionRepo.CreateCollectionWithCards(collection, resolved.Cards, resolved.ExistingCardIds)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	result.Report = &entity.ImportReport{
+		CollectionId: created.Id,
+		Name:         created.Name,
+		CardsAmount:  resolved.amount(),
+		Skipped:      result.Skipped,
+		Duplicates:   resolved.Duplicates,
+	}
+	return result, nil
+}
+
+// appendImportedCards adds the cards to an existing collection of the user,
+// the cards inherit the languages of the collection
+func (uc *usecase) appendImportedCards(userId, collectionId uuid.UUID, cards []*entity.Card, duplicates entity.DuplicatePolicy) (*entity.ImportReport, error) {
+	collection, err := uc.getCollection(collectionId)
+	if err != nil {
+		return nil, err
+	}
+	if collection.AuthorId != userId {
+		return nil, ErrUnauthorized
+	}
+	err = setCollectionLanguages(collection, cards)
+	if err != nil {
+		return nil, err
+	}
+	err = normalizeCardLevels(cards)
+	if err != nil {
+		return nil, err
+	}
+	err = normalizeCardBodies(cards)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := uc.resolveDuplicates(userId, cards, duplicates)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if len(resolved.Cards) > 0 {
+		err = uc.cardRepo.CreateMultipleCards(collection.Id, resolved.Cards, userId)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+	}
+	// A merged card already in the collection is not added a second time
+	inCollection, err := uc.collectionCardIds(collection.Id, userId)
+	if err != nil {
+		return nil, err
+	}
+	added := uint32(len(resolved.Cards))
+	for _, cardId := range resolved.ExistingCardIds {
+		if inCollection[cardId] {
+			continue
+		}
+		err = uc.cardRepo.AssignCardToCollection(collection.Id, cardId)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		added++
+	}
+	return &entity.ImportReport{
+		CollectionId: collection.Id,
+		Name:         collection.Name,
+		CardsAmount:  added,
+		Skipped:      []*entity.ImportSkip{},
+		Duplicates:   resolved.Duplicates,
+	}, nil
+}
+
+// parseTextCards splits the text into rows and every row into a word and a definition at the first term separator
+func parseTextCards(text, termSeparator, rowSeparator string) *entity.TextImportResult {
+	result := &entity.TextImportResult{
+		Cards:   []*entity.Card{},
+		Skipped: []*entity.ImportSkip{},
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	for i, row := range strings.Split(text, rowSeparator) {
+		row = strings.TrimSpace(row)
+		if row == "" {
+			continue
+		}
+		source := fmt.Sprintf("row %d", i+1)
+		parts := strings.SplitN(row, termSeparator, 2)
+		if len(parts) < 2 {
+			result.Skipped = append(result.Skipped, &entity.ImportSkip{Source: source, Reason: "term separator not found in " + row})
+			continue
+		}
+		word, definition := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if word == "" {
+			result.Skipped = append(result.Skipped, &entity.ImportSkip{Source: source, Reason: "word is empty"})
+			continue
+		}
+		if utf8.RuneCountInString(word) > cardWordMaxLen {
+			result.Skipped = append(result.Skipped, &entity.ImportSkip{Source: source, Reason: fmt.Sprintf("word is longer than %d characters", cardWordMaxLen)})
+			continue
+		}
+		if definition == "" {
+			result.Skipped = append(result.Skipped, &entity.ImportSkip{Source: source, Reason: "definition is empty"})
+			continue
+		}
+		result.Cards = append(result.Cards, &entity.Card{
+			Word:       word,
+			Definition: definition,
+		})
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package collection_usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// StartTrendingRefresh recomputes trending scores right away and then once per interval,
+// so that trending requests only read precomputed scores
+func (uc *usecase) StartTrendingRefresh(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := uc.RefreshTrendingCollections(); err != nil {
+				logrus.Errorf("%v: %v", ErrUnexpected, err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func (uc *usecase) RefreshTrendingCollections() error {
+	for _, window := range entity.TrendingWindows {
+		err := uc.collectionRepo.RefreshTrendingCollections(window)
+		if err != nil {
+			return fmt.Errorf("refresh trending %s: %w", window, err)
+		}
+	}
+	return nil
+}
+
+func (uc *usecase) GetTrendingCollections(userId uuid.UUID, window entity.TrendingWindow, page, size int) ([]*entity.UserCollectionResponse, error) {
+	if !window.IsValid() {
+		return nil, ErrInvalidTrendingWindow
+	}
+	collectionResponses := []*entity.UserCollectionResponse{}
+	limit := size
+	offset := (page - 1) * size
+
+	collections, err := uc.collectionRepo.GetTrendingCollections(window, limit, offset)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+
+	for _, collection := range collections {
+		collectionAuthor, err := uc.userRepo.GetUserById(collection.AuthorId)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+
+		collectionMetrics, err := uc.collectionRepo.GetCollectionMetrics(collection.Id)
+		if err != nil && !errors.Is(err, repositoryIntf.ErrCollectionMetricsNotFound) {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		collectionUserProgress, err := uc.collectionRepo.GetCollectionUserProgress(collection.Id, userId)
+		if err != nil && !errors.Is(err, repositoryIntf.ErrCollectionUserProgressNotFound) {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		collectionUserMetrics, err := uc.collectionRepo.GetCollectionUserMetrics(collection.Id, userId)
+		if err != nil && !errors.Is(err, repositoryIntf.ErrCollectionUserMetricsNotFound) {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		totalCards, err := uc.collectionRepo.GetTotalCardsInCollection(collection.Id)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		createdDate := time.Date(collection.CreatedAt.Year(),
+			collection.CreatedAt.Month(),
+			collection.CreatedAt.Day(), 0, 0, 0, 0, time.UTC)
+		createdDateFormat := fmt.Sprintf("%v %v, %v", createdDate.Month(), createdDate.Day(), createdDate.Year())
+
+		collectionResponse := &entity.UserCollectionResponse{
+			Id:               collection.Id,
+			Name:             collection.Name,
+			AuthorName:       collectionAuthor.Username,
+			Topics:           collection.Topics,
+			SourceLanguage:   collection.SourceLanguage,
+			TargetLanguage:   collection.TargetLanguage,
+			Level:            collection.Level,
+			TotalCards:       totalCards,
+			Likes:            collectionMetrics.Likes,
+			Dislikes:         collectionMetrics.Dislikes,
+			Views:            collectionMetrics.Views,
+			AverageRating:    collectionMetrics.AverageRating,
+			Ratings:          collectionMetrics.Ratings,
+			Mastered:         collectionUserProgress.Mastered,
+			Reviewing:        collectionUserProgress.Reviewing,
+			Learning:         collectionUserProgress.Learning,
+			Starred:          collectionUserMetrics.Starred,
+			IsLikedByUser:    collectionUserMetrics.Liked,
+			IsDislikedByUser: collectionUserMetrics.Disliked,
+			IsViewedByUser:   collectionUserMetrics.Viewed,
+			CreatedDate:      createdDateFormat,
+		}
+
+		collectionResponses = append(collectionResponses, collectionResponse)
+	}
+
+	return collectionResponses, nil
+}
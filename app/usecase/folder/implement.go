@@ -0,0 +1,224 @@
+package folder_usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type usecase struct {
+	folderRepo     repositoryIntf.FolderRepository
+	collectionRepo repositoryIntf.CollectionRepository
+}
+
+func New(
+	folderRepo repositoryIntf.FolderRepository,
+	collectionRepo repositoryIntf.CollectionRepository,
+) UseCase {
+	return &usecase{
+		folderRepo:     folderRepo,
+		collectionRepo: collectionRepo,
+	}
+}
+
+func (uc *usecase) CreateFolder(userId uuid.UUID, request entity.CreateFolderRequest) (*entity.Folder, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, ErrInvalidName
+	}
+	if request.ParentId != nil {
+		if err := uc.checkFolderExists(*request.ParentId, userId); err != nil {
+			return nil, err
+		}
+	}
+
+	folder, err := uc.folderRepo.CreateFolder(entity.Folder{
+		Name:     name,
+		ParentId: request.ParentId,
+		UserId:   userId,
+	})
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return folder, nil
+}
+
+func (uc *usecase) RenameFolder(id, userId uuid.UUID, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrInvalidName
+	}
+	folder, err := uc.getFolder(id, userId)
+	if err != nil {
+		return err
+	}
+	folder.Name = name
+	err = uc.folderRepo.UpdateFolder(*folder)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+func (uc *usecase) MoveFolder(id, userId uuid.UUID, parentId *uuid.UUID) error {
+	folder, err := uc.getFolder(id, userId)
+	if err != nil {
+		return err
+	}
+
+	if parentId != nil {
+		folders, err := uc.folderRepo.GetUserFolders(userId)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+		parents := map[uuid.UUID]*uuid.UUID{}
+		for _, f := range folders {
+			parents[f.Id] = f.ParentId
+		}
+		if _, ok := parents[*parentId]; !ok {
+			return ErrNotFound
+		}
+		// Walk up from the new parent, reaching the moved folder means a cycle
+		for current := parentId; current != nil; current = parents[*current] {
+			if *current == id {
+				return ErrFolderCycle
+			}
+		}
+	}
+
+	folder.ParentId = parentId
+	err = uc.folderRepo.UpdateFolder(*folder)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+func (uc *usecase) DeleteFolder(id, userId uuid.UUID) error {
+	err := uc.folderRepo.DeleteFolder(id, userId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrFolderNotFound) {
+			return ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+func (uc *usecase) MoveCollectionToFolder(collectionId, userId uuid.UUID, folderId *uuid.UUID) error {
+	_, err := uc.collectionRepo.GetCollection(collectionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if folderId != nil {
+		if err := uc.checkFolderExists(*folderId, userId); err != nil {
+			return err
+		}
+	}
+
+	err = uc.folderRepo.AssignCollectionToFolder(userId, collectionId, folderId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+// GetFolderTree returns the root folders of the user, every folder carries the progress
+// summed over its own collections and all of its subfolders
+func (uc *usecase) GetFolderTree(userId uuid.UUID) ([]*entity.FolderTree, error) {
+	folders, err := uc.folderRepo.GetUserFolders(userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	collections, err := uc.folderRepo.GetFolderCollections(userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+
+	nodes := map[uuid.UUID]*entity.FolderTree{}
+	for _, folder := range folders {
+		nodes[folder.Id] = &entity.FolderTree{
+			Id:          folder.Id,
+			Name:        folder.Name,
+			ParentId:    folder.ParentId,
+			Collections: []*entity.FolderCollection{},
+			Children:    []*entity.FolderTree{},
+		}
+	}
+	for _, collection := range collections {
+		if node, ok := nodes[collection.FolderId]; ok {
+			node.Collections = append(node.Collections, collection)
+		}
+	}
+
+	roots := []*entity.FolderTree{}
+	for _, folder := range folders {
+		node := nodes[folder.Id]
+		var parent *entity.FolderTree
+		if folder.ParentId != nil {
+			parent = nodes[*folder.ParentId]
+		}
+		if parent != nil {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	for _, root := range roots {
+		sumFolderProgress(root)
+	}
+	return roots, nil
+}
+
+func sumFolderProgress(node *entity.FolderTree) {
+	node.TotalCards, node.Mastered, node.Reviewing, node.Learning = 0, 0, 0, 0
+	for _, collection := range node.Collections {
+		node.TotalCards += collection.TotalCards
+		node.Mastered += collection.Mastered
+		node.Reviewing += collection.Reviewing
+		node.Learning += collection.Learning
+	}
+	for _, child := range node.Children {
+		sumFolderProgress(child)
+		node.TotalCards += child.TotalCards
+		node.Mastered += child.Mastered
+		node.Reviewing += child.Reviewing
+		node.Learning += child.Learning
+	}
+}
+
+func (uc *usecase) getFolder(id, userId uuid.UUID) (*entity.Folder, error) {
+	folder, err := uc.folderRepo.GetFolder(id, userId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrFolderNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return folder, nil
+}
+
+func (uc *usecase) checkFolderExists(id, userId uuid.UUID) error {
+	_, err := uc.getFolder(id, userId)
+	return err
+}
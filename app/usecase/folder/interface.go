@@ -0,0 +1,22 @@
+package folder_usecase
+
+import (
+	"errors"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+var ErrUnexpected = errors.New("Internal error")
+var ErrNotFound = errors.New("ErrNotFound")
+var ErrInvalidName = errors.New("Folder name is required")
+var ErrFolderCycle = errors.New("Folder cannot be moved into itself or one of its subfolders")
+
+type UseCase interface {
+	CreateFolder(userId uuid.UUID, request entity.CreateFolderRequest) (*entity.Folder, error)
+	RenameFolder(id, userId uuid.UUID, name string) error
+	MoveFolder(id, userId uuid.UUID, parentId *uuid.UUID) error
+	DeleteFolder(id, userId uuid.UUID) error
+	MoveCollectionToFolder(collectionId, userId uuid.UUID, folderId *uuid.UUID) error
+	GetFolderTree(userId uuid.UUID) ([]*entity.FolderTree, error)
+}
@@ -0,0 +1,154 @@
+package lexeme_usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const clusterBatchSize = 500
+
+// lexemeKey is the normalized word and the language cards are clustered by
+type lexemeKey struct {
+	word     string
+	language string
+}
+
+// StartLexemeClustering links the new and edited cards to their lexemes right away and then once per interval
+func (uc *usecase) StartLexemeClustering(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := uc.RunLexemeClustering(); err != nil {
+				logrus.Errorf("%v: %v", ErrUnexpected, err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// ClusterCards runs the clustering job on request of an admin
+func (uc *usecase) ClusterCards(userId uuid.UUID) (*entity.LexemeClusterResult, error) {
+	err := uc.checkAdmin(userId)
+	if err != nil {
+		return nil, err
+	}
+	result, err := uc.RunLexemeClustering()
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return result, nil
+}
+
+// RunLexemeClustering links every card without a lexeme to the lexeme of its normalized word and language,
+// creating the missing lexemes, then refreshes the card count and the canonical card of the lexemes
+func (uc *usecase) RunLexemeClustering() (*entity.LexemeClusterResult, error) {
+	uc.clusterLock.Lock()
+	defer uc.clusterLock.Unlock()
+
+	result := &entity.LexemeClusterResult{}
+	// Cards whose word is blank stay without a lexeme, the batches go on after them
+	afterId := uuid.Nil
+	for {
+		cards, err := uc.lexemeRepo.GetUnlinkedCards(afterId, clusterBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("get unlinked cards: %w", err)
+		}
+		if len(cards) == 0 {
+			break
+		}
+		afterId = cards[len(cards)-1].Id
+		linked, created, err := uc.clusterCards(cards)
+		if err != nil {
+			return nil, err
+		}
+		result.LinkedCards += linked
+		result.CreatedLexemes += created
+		if len(cards) < clusterBatchSize {
+			break
+		}
+	}
+	lexemes, err := uc.lexemeRepo.RefreshLexemes()
+	if err != nil {
+		return nil, fmt.Errorf("refresh lexemes: %w", err)
+	}
+	result.Lexemes = lexemes
+	return result, nil
+}
+
+// clusterCards links the cards of a batch and returns how many cards were linked and lexemes created
+func (uc *usecase) clusterCards(cards []*entity.Card) (int, int, error) {
+	keys := []lexemeKey{}
+	words := []string{}
+	wordAdded := map[string]bool{}
+	cardIds := map[lexemeKey][]uuid.UUID{}
+	for _, card := range cards {
+		key := lexemeKey{
+			word:     entity.NormalizeLexemeWord(card.Word),
+			language: entity.LexemeLanguage(card.SourceLanguage),
+		}
+		if key.word == "" {
+			continue
+		}
+		if _, ok := cardIds[key]; !ok {
+			keys = append(keys, key)
+		}
+		if !wordAdded[key.word] {
+			wordAdded[key.word] = true
+			words = append(words, key.word)
+		}
+		cardIds[key] = append(cardIds[key], card.Id)
+	}
+
+	lexemeIds, err := uc.getLexemeIds(words)
+	if err != nil {
+		return 0, 0, err
+	}
+	missing := []*entity.Lexeme{}
+	for _, key := range keys {
+		if _, ok := lexemeIds[key]; !ok {
+			missing = append(missing, &entity.Lexeme{Word: key.word, Language: key.language})
+		}
+	}
+	created, err := uc.lexemeRepo.CreateLexemes(missing)
+	if err != nil {
+		return 0, 0, fmt.Errorf("create lexemes: %w", err)
+	}
+	if len(missing) > 0 {
+		lexemeIds, err = uc.getLexemeIds(words)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	linked := 0
+	for _, key := range keys {
+		lexemeId, ok := lexemeIds[key]
+		if !ok {
+			continue
+		}
+		amount, err := uc.lexemeRepo.LinkCardsToLexeme(lexemeId, cardIds[key])
+		if err != nil {
+			return 0, 0, fmt.Errorf("link cards to lexeme %s: %w", lexemeId, err)
+		}
+		linked += amount
+	}
+	return linked, created, nil
+}
+
+func (uc *usecase) getLexemeIds(words []string) (map[lexemeKey]uuid.UUID, error) {
+	lexemes, err := uc.lexemeRepo.GetLexemesByWords(words)
+	if err != nil {
+		return nil, fmt.Errorf("get lexemes: %w", err)
+	}
+	lexemeIds := map[lexemeKey]uuid.UUID{}
+	for _, lexeme := range lexemes {
+		lexemeIds[lexemeKey{word: lexeme.Word, language: lexeme.Language}] = lexeme.Id
+	}
+	return lexemeIds, nil
+}
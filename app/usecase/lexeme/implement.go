@@ -0,0 +1,97 @@
+package lexeme_usecase
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const similarCardsMaxSize = 50
+
+type usecase struct {
+	lexemeRepo repositoryIntf.LexemeRepository
+	cardRepo   repositoryIntf.CardRepository
+	userRepo   repositoryIntf.UserRepository
+	// clusterLock keeps the periodic and the requested clustering runs from overlapping
+	clusterLock sync.Mutex
+}
+
+func New(
+	lexemeRepo repositoryIntf.LexemeRepository,
+	cardRepo repositoryIntf.CardRepository,
+	userRepo repositoryIntf.UserRepository,
+) UseCase {
+	return &usecase{
+		lexemeRepo: lexemeRepo,
+		cardRepo:   cardRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (uc *usecase) GetLexeme(id uuid.UUID) (*entity.Lexeme, error) {
+	lexeme, err := uc.lexemeRepo.GetLexeme(id)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrLexemeNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return lexeme, nil
+}
+
+// GetSimilarCards returns the existing cards for the word so that authors can add one of them to their
+// collection instead of writing a new card. The cards are looked up by the lexeme of the word and language,
+// cards the clustering job did not link yet are matched by their word.
+func (uc *usecase) GetSimilarCards(word, language string, size int) (*entity.SimilarCards, error) {
+	normalized := entity.NormalizeLexemeWord(word)
+	if normalized == "" {
+		return nil, ErrInvalidWord
+	}
+	tag, err := entity.NormalizeLanguageTag(language)
+	if err != nil {
+		return nil, ErrInvalidLanguage
+	}
+	if size > similarCardsMaxSize {
+		size = similarCardsMaxSize
+	}
+	lexemeLanguage := entity.LexemeLanguage(tag)
+
+	resp := &entity.SimilarCards{}
+	lexemeId, canonicalCardId := uuid.Nil, uuid.Nil
+	lexeme, err := uc.lexemeRepo.GetLexemeByWord(normalized, lexemeLanguage)
+	if err != nil && !errors.Is(err, repositoryIntf.ErrLexemeNotFound) {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if err == nil {
+		resp.Lexeme = lexeme
+		lexemeId = lexeme.Id
+		if lexeme.CanonicalCardId != nil {
+			canonicalCardId = *lexeme.CanonicalCardId
+		}
+	}
+	resp.Cards, err = uc.cardRepo.GetSimilarCards(normalized, lexemeLanguage, lexemeId, canonicalCardId, size)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return resp, nil
+}
+
+func (uc *usecase) checkAdmin(userId uuid.UUID) error {
+	user, err := uc.userRepo.GetUserById(userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if user.Role != entity.UserRole_Admin {
+		return ErrUnauthorized
+	}
+	return nil
+}
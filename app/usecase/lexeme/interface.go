@@ -0,0 +1,23 @@
+package lexeme_usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+var ErrUnexpected = errors.New("Internal error")
+var ErrUnauthorized = errors.New("ErrUnauthorized")
+var ErrNotFound = errors.New("ErrNotFound")
+var ErrInvalidWord = errors.New("Word must not be empty")
+var ErrInvalidLanguage = errors.New("Language must be a valid BCP-47 tag")
+
+type UseCase interface {
+	GetLexeme(id uuid.UUID) (*entity.Lexeme, error)
+	GetSimilarCards(word, language string, size int) (*entity.SimilarCards, error)
+	ClusterCards(userId uuid.UUID) (*entity.LexemeClusterResult, error)
+	RunLexemeClustering() (*entity.LexemeClusterResult, error)
+	StartLexemeClustering(interval time.Duration)
+}
@@ -0,0 +1,137 @@
+package moderation_usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type usecase struct {
+	moderationRepo repositoryIntf.ModerationRepository
+	userRepo       repositoryIntf.UserRepository
+}
+
+func New(
+	moderationRepo repositoryIntf.ModerationRepository,
+	userRepo repositoryIntf.UserRepository,
+) UseCase {
+	return &usecase{
+		moderationRepo: moderationRepo,
+		userRepo:       userRepo,
+	}
+}
+
+func (uc *usecase) ReportContent(targetType entity.ReportTargetType, targetId, userId uuid.UUID, request entity.ReportRequest) (*entity.Report, error) {
+	if !request.Reason.IsValid() {
+		return nil, ErrInvalidReason
+	}
+	authorId, err := uc.moderationRepo.GetReportTargetAuthor(targetType, targetId)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrReportTargetNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if authorId == userId {
+		return nil, ErrForbiddenSelfRequest
+	}
+
+	reported, err := uc.moderationRepo.HasOpenReport(userId, targetType, targetId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if reported {
+		return nil, ErrAlreadyReported
+	}
+
+	report, err := uc.moderationRepo.CreateReport(entity.Report{
+		ReporterId: userId,
+		TargetType: targetType,
+		TargetId:   targetId,
+		AuthorId:   authorId,
+		Reason:     request.Reason,
+		Details:    strings.TrimSpace(request.Details),
+	})
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return report, nil
+}
+
+func (uc *usecase) GetReports(userId uuid.UUID, status entity.ReportStatus, page, size int) (*entity.ReportPagination, error) {
+	if err := uc.checkAdmin(userId); err != nil {
+		return nil, err
+	}
+	limit := size
+	offset := (page - 1) * size
+
+	reports, total, err := uc.moderationRepo.GetReports(status, limit, offset)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return &entity.ReportPagination{
+		Reports: reports,
+		Page:    page,
+		Size:    size,
+		Total:   total,
+	}, nil
+}
+
+// ModerateReport applies the moderator's decision to the reported content,
+// all open reports against the same content are resolved together
+func (uc *usecase) ModerateReport(id, userId uuid.UUID, request entity.ModerationActionRequest) error {
+	if !request.Action.IsValid() {
+		return ErrInvalidAction
+	}
+	if err := uc.checkAdmin(userId); err != nil {
+		return err
+	}
+	report, err := uc.moderationRepo.GetReport(id)
+	if err != nil {
+		if errors.Is(err, repositoryIntf.ErrReportNotFound) {
+			return ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if report.Status != entity.ReportStatus_Open {
+		return ErrAlreadyResolved
+	}
+
+	err = uc.moderationRepo.ApplyModerationAction(*report, request.Action, userId, strings.TrimSpace(request.Note))
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return nil
+}
+
+func (uc *usecase) GetUserWarnings(userId uuid.UUID) ([]*entity.UserWarning, error) {
+	warnings, err := uc.moderationRepo.GetUserWarnings(userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	return warnings, nil
+}
+
+func (uc *usecase) checkAdmin(userId uuid.UUID) error {
+	user, err := uc.userRepo.GetUserById(userId)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if user.Role != entity.UserRole_Admin {
+		return ErrUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package moderation_usecase
+
+import (
+	"errors"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+var ErrUnexpected = errors.New("Internal error")
+var ErrUnauthorized = errors.New("ErrUnauthorized")
+var ErrNotFound = errors.New("ErrNotFound")
+var ErrForbiddenSelfRequest = errors.New("Self request is forbidden")
+var ErrInvalidReason = errors.New("Reason must be one of spam, abusive, copyright, inappropriate, other")
+var ErrInvalidAction = errors.New("Action must be one of hide, delete, dismiss, warn")
+var ErrAlreadyReported = errors.New("Content is already reported by this user")
+var ErrAlreadyResolved = errors.New("Report is already resolved")
+
+type UseCase interface {
+	ReportContent(targetType entity.ReportTargetType, targetId, userId uuid.UUID, request entity.ReportRequest) (*entity.Report, error)
+	GetReports(userId uuid.UUID, status entity.ReportStatus, page, size int) (*entity.ReportPagination, error)
+	ModerateReport(id, userId uuid.UUID, request entity.ModerationActionRequest) error
+	GetUserWarnings(userId uuid.UUID) ([]*entity.UserWarning, error)
+}
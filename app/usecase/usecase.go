@@ -3,15 +3,19 @@ package usecase
 import (
 	"context"
 	"log"
-	"os"
+	"time"
 
 	// card_usecase "github.com/flash-cards-vocab/backend/app/usecase/card"
 	"cloud.google.com/go/storage"
 	cardUC "github.com/flash-cards-vocab/backend/app/usecase/card"
 	collectionUC "github.com/flash-cards-vocab/backend/app/usecase/collection"
+	folderUC "github.com/flash-cards-vocab/backend/app/usecase/folder"
+	lexemeUC "github.com/flash-cards-vocab/backend/app/usecase/lexeme"
+	moderationUC "github.com/flash-cards-vocab/backend/app/usecase/moderation"
 	userUC "github.com/flash-cards-vocab/backend/app/usecase/user"
 	"github.com/flash-cards-vocab/backend/pkg/application"
 	"github.com/flash-cards-vocab/backend/pkg/repository"
+	"github.com/flash-cards-vocab/backend/pkg/tts"
 	"google.golang.org/api/option"
 )
 
@@ -20,6 +24,9 @@ type Usecase struct {
 	UserUsecase       userUC.UseCase
 	CollectionUsecase collectionUC.UseCase
 	CardUsecase       cardUC.UseCase
+	FolderUsecase     folderUC.UseCase
+	ModerationUsecase moderationUC.UseCase
+	LexemeUsecase     lexemeUC.UseCase
 }
 
 func Get(app *application.Application) *Usecase {
@@ -35,14 +42,29 @@ func Get(app *application.Application) *Usecase {
 		log.Fatalf("Failed to create client: %v", err)
 	}
 
-	userUsecase := userUC.New(repo.UserRepository, repo.CompanyRepository, repo.CollectionRepository, repo.CardRepository)
-	collectionUsecase := collectionUC.New(repo.CollectionRepository, repo.CardRepository, repo.UserRepository, gcsClient, "flashcards-images", "dev")
-	cardUsecase := cardUC.New(repo.CardRepository, repo.CollectionRepository, gcsClient, "flashcards-images", "dev")
+	userUsecase := userUC.New(repo.UserRepository, repo.CompanyRepository, repo.CollectionRepository, repo.CardRepository, repo.UserExportRepository, gcsClient, "flashcards-images", "dev")
+	collectionUsecase := collectionUC.New(repo.CollectionRepository, repo.CardRepository, repo.UserRepository, repo.ImportJobRepository, gcsClient, "flashcards-images", "dev")
+	ttsProvider, err := tts.New(app.Config.TTSProvider, app.Config.Environment)
+	if err != nil {
+		log.Fatalf("Failed to create speech provider: %v", err)
+	}
+	cardUsecase := cardUC.New(repo.CardRepository, repo.CollectionRepository, gcsClient, "flashcards-images", "dev", ttsProvider)
+	folderUsecase := folderUC.New(repo.FolderRepository, repo.CollectionRepository)
+	moderationUsecase := moderationUC.New(repo.ModerationRepository, repo.UserRepository)
+	lexemeUsecase := lexemeUC.New(repo.LexemeRepository, repo.CardRepository, repo.UserRepository)
+
+	collectionUsecase.StartTrendingRefresh(time.Minute * 15)
+	collectionUsecase.ResumeImportJobs()
+	userUsecase.ResumeDataExports()
+	lexemeUsecase.StartLexemeClustering(time.Hour * 6)
 
 	return &Usecase{
 		App:               app,
 		UserUsecase:       userUsecase,
 		CollectionUsecase: collectionUsecase,
 		CardUsecase:       cardUsecase,
+		FolderUsecase:     folderUsecase,
+		ModerationUsecase: moderationUsecase,
+		LexemeUsecase:     lexemeUsecase,
 	}
 }
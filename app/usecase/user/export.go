@@ -0,0 +1,187 @@
+package user_usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// RequestDataExport starts building the personal data archive of the user in the background,
+// the returned export is polled with GetDataExport until it is completed
+func (uc *usecase) RequestDataExport(userId uuid.UUID) (*entity.UserDataExport, error) {
+	export, err := uc.userExportRepo.CreateUserExport(entity.UserDataExport{UserId: userId})
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	go uc.runDataExport(*export)
+	return export, nil
+}
+
+// ResumeDataExports restarts the exports that were pending or running when the server stopped,
+// the archive of an interrupted export is built again from the start
+func (uc *usecase) ResumeDataExports() {
+	exports, err := uc.userExportRepo.GetUnfinishedUserExports()
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return
+	}
+	for _, export := range exports {
+		go uc.runDataExport(*export)
+	}
+}
+
+func (uc *usecase) GetDataExport(id, userId uuid.UUID) (*entity.UserDataExport, error) {
+	export, err := uc.userExportRepo.GetUserExport(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserExportNotFound) {
+			return nil, ErrNotFound
+		}
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+		return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+	}
+	if export.UserId != userId {
+		return nil, ErrUnauthorized
+	}
+	if export.Status == entity.UserDataExportStatus_Completed {
+		export.DownloadUrl, err = uc.exportDownloadUrl(export.ObjectName)
+		if err != nil {
+			logrus.Errorf("%v: %v", ErrUnexpected, err)
+			return nil, fmt.Errorf("%w: %v", ErrUnexpected, "Unexpected error")
+		}
+	}
+	return export, nil
+}
+
+func (uc *usecase) runDataExport(export entity.UserDataExport) {
+	export.Status = entity.UserDataExportStatus_Running
+	err := uc.userExportRepo.UpdateUserExport(export)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+	}
+
+	archive, err := uc.buildDataArchive(export.UserId)
+	if err == nil {
+		export.ObjectName, err = uc.uploadExportArchive(archive, export.UserId.String(), export.Id.String())
+	}
+	completedAt := time.Now()
+	export.CompletedAt = &completedAt
+	if err != nil {
+		logrus.Errorf("%v: user data export %v: %v", ErrUnexpected, export.Id, err)
+		export.Status = entity.UserDataExportStatus_Failed
+		export.Error = "The archive could not be created, request a new export"
+	} else {
+		export.Status = entity.UserDataExportStatus_Completed
+	}
+	err = uc.userExportRepo.UpdateUserExport(export)
+	if err != nil {
+		logrus.Errorf("%v: %v", ErrUnexpected, err)
+	}
+}
+
+// buildDataArchive zips data.json with everything stored about the user and the images of their cards
+func (uc *usecase) buildDataArchive(userId uuid.UUID) (*bytes.Buffer, error) {
+	profile, err := uc.userRepo.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+	profile.Password = ""
+
+	data := &entity.UserDataArchive{
+		ExportedAt: time.Now().UTC(),
+		Profile:    profile,
+		Cards:      []*entity.UserDataCard{},
+	}
+	data.Collections, err = uc.userExportRepo.GetUserAuthoredCollections(userId)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := uc.userExportRepo.GetUserAuthoredCards(userId)
+	if err != nil {
+		return nil, err
+	}
+	data.Folders, err = uc.userExportRepo.GetUserFolders(userId)
+	if err != nil {
+		return nil, err
+	}
+	data.FolderCollections, err = uc.userExportRepo.GetUserFolderCollections(userId)
+	if err != nil {
+		return nil, err
+	}
+	data.CollectionMetrics, err = uc.userExportRepo.GetUserCollectionMetrics(userId)
+	if err != nil {
+		return nil, err
+	}
+	data.CollectionProgress, err = uc.userExportRepo.GetUserCollectionProgress(userId)
+	if err != nil {
+		return nil, err
+	}
+	data.CardProgress, err = uc.userExportRepo.GetUserCardProgress(userId)
+	if err != nil {
+		return nil, err
+	}
+	data.CardNotes, err = uc.userExportRepo.GetUserCardNotes(userId)
+	if err != nil {
+		return nil, err
+	}
+	data.CardMnemonics, err = uc.userExportRepo.GetUserCardMnemonics(userId)
+	if err != nil {
+		return nil, err
+	}
+	data.CardMetrics, err = uc.userExportRepo.GetUserCardMetrics(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	// Images are stored once per URL, cards sharing an image point to the same file
+	imageFiles := map[string]string{}
+	for _, card := range cards {
+		dataCard := &entity.UserDataCard{Card: *card}
+		if card.ImageUrl != "" {
+			imageFile, ok := imageFiles[card.ImageUrl]
+			if !ok {
+				image, extension, err := uc.downloadImage(card.ImageUrl)
+				if err != nil {
+					// The image URL stays in data.json when the image itself is not available
+					logrus.Warnf("failed to download image of card %s: %v", card.Id, err)
+				} else {
+					imageFile = "images/" + card.Id.String() + extension
+					w, err := archive.Create(imageFile)
+					if err != nil {
+						return nil, err
+					}
+					if _, err = w.Write(image); err != nil {
+						return nil, err
+					}
+				}
+				imageFiles[card.ImageUrl] = imageFile
+			}
+			dataCard.ImageFile = imageFile
+		}
+		data.Cards = append(data.Cards, dataCard)
+	}
+
+	w, err := archive.Create("data.json")
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(data); err != nil {
+		return nil, err
+	}
+	if err = archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"cloud.google.com/go/storage"
 	"github.com/flash-cards-vocab/backend/pkg/helpers"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -35,6 +36,10 @@ type usecase struct {
 	companyRepo    repository.CompanyRepository
 	collectionRepo repository.CollectionRepository
 	cardRepo       repository.CardRepository
+	userExportRepo repository.UserExportRepository
+	gcsClient      *storage.Client
+	bucketName     string
+	envPrefix      string
 }
 
 func New(
@@ -42,13 +47,20 @@ func New(
 	companyRepo repository.CompanyRepository,
 	collectionRepo repository.CollectionRepository,
 	cardRepo repository.CardRepository,
-
+	userExportRepo repository.UserExportRepository,
+	gcsClient *storage.Client,
+	bucketName string,
+	envPrefix string,
 ) UseCase {
 	return &usecase{
 		userRepo:       userRepo,
 		companyRepo:    companyRepo,
 		collectionRepo: collectionRepo,
 		cardRepo:       cardRepo,
+		userExportRepo: userExportRepo,
+		gcsClient:      gcsClient,
+		bucketName:     bucketName,
+		envPrefix:      envPrefix,
 	}
 }
 
@@ -21,4 +21,7 @@ type UseCase interface {
 	Login(user entity.UserLogin) (*entity.UserWithAuthToken, error)
 	GetProfile(userId uuid.UUID) (*entity.ProfileInfoResp, error)
 	UsernameExists(username string) (bool, error)
+	RequestDataExport(userId uuid.UUID) (*entity.UserDataExport, error)
+	GetDataExport(id, userId uuid.UUID) (*entity.UserDataExport, error)
+	ResumeDataExports()
 }
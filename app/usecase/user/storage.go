@@ -0,0 +1,52 @@
+package user_usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/flash-cards-vocab/backend/pkg/media"
+)
+
+const userExportsLocation = "user_exports"
+
+// Download links of the export archive are valid for this long, a new link is signed on every request
+const exportDownloadUrlExpiry = time.Minute * 30
+
+// downloadImage returns the content of a card image and the extension matching its content
+func (uc *usecase) downloadImage(imageUrl string) ([]byte, string, error) {
+	data, filename, err := media.Download(uc.gcsClient, uc.bucketName, imageUrl)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, path.Ext(filename), nil
+}
+
+// uploadExportArchive stores the archive of the export and returns its object name
+func (uc *usecase) uploadExportArchive(data io.Reader, userId, exportId string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	objectName := uc.envPrefix + "/" + userExportsLocation + "/" + userId + "/" + exportId + ".zip"
+	wc := uc.gcsClient.Bucket(uc.bucketName).Object(objectName).NewWriter(ctx)
+	wc.ContentType = "application/zip"
+	if _, err := io.Copy(wc, data); err != nil {
+		wc.Close()
+		return "", err
+	}
+	if err := wc.Close(); err != nil {
+		return "", err
+	}
+	return objectName, nil
+}
+
+func (uc *usecase) exportDownloadUrl(objectName string) (string, error) {
+	return uc.gcsClient.Bucket(uc.bucketName).SignedURL(objectName, &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(exportDownloadUrlExpiry),
+		Scheme:  storage.SigningSchemeV4,
+	})
+}
@@ -27,6 +27,9 @@ type Config struct {
 	GCSPrefix     string `envconfig:"GCS_PREFIX" default:"dev"`
 	GCSAPIKey     string `envconfig:"GCS_API_KEY" default:""`
 	GCSJSONAPIKey string `envconfig:"GCS_JSON_API_KEY" default:""`
+
+	// Speech provider generating card audio, empty disables it and "stub" is only allowed in dev
+	TTSProvider string `envconfig:"TTS_PROVIDER" yaml:"TTS_PROVIDER" default:""`
 }
 
 func New() *Config {
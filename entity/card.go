@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -8,22 +10,55 @@ type Card struct {
 	Id         uuid.UUID `json:"id,omitempty"`
 	Word       string    `json:"word,omitempty"`
 	ImageUrl   string    `json:"imageUrl,omitempty"`
+	AudioUrl   string    `json:"audioUrl,omitempty"`
 	Definition string    `json:"definition,omitempty"`
 	Sentence   string    `json:"sentence,omitempty"`
 	Antonyms   string    `json:"antonyms,omitempty"`
 	Synonyms   string    `json:"synonyms,omitempty"`
-	AuthorId   uuid.UUID `json:"authorId,omitempty"`
+	// SynonymList and AntonymList may link the related words to their cards, Synonyms and Antonyms summarize them
+	SynonymList []*RelatedWord `json:"synonymList,omitempty"`
+	AntonymList []*RelatedWord `json:"antonymList,omitempty"`
+	// Senses are the meanings of the word, Definition and Sentence summarize them for old clients
+	Senses []*CardSense `json:"senses,omitempty"`
+	// Pronunciation is the IPA transcription of the word
+	Pronunciation string `json:"pronunciation,omitempty"`
+	// Gender and Plural are set for the nouns of languages that inflect them
+	Gender         string    `json:"gender,omitempty"`
+	Plural         string    `json:"plural,omitempty"`
+	AuthorId       uuid.UUID `json:"authorId,omitempty"`
+	SourceLanguage string    `json:"sourceLanguage,omitempty"`
+	TargetLanguage string    `json:"targetLanguage,omitempty"`
+	Level          string    `json:"level,omitempty"`
+	// LexemeId links the card to its canonical word, it is set by the clustering job
+	LexemeId *uuid.UUID `json:"lexemeId,omitempty"`
+	// Hidden is set by moderation, only the author still sees a hidden card
+	Hidden bool `json:"hidden,omitempty"`
 }
 type CardWithOccurence struct {
-	Id         uuid.UUID `json:"id,omitempty"`
-	Word       string    `json:"word,omitempty"`
-	ImageUrl   string    `json:"imageUrl,omitempty"`
-	Definition string    `json:"definition,omitempty"`
-	Sentence   string    `json:"sentence,omitempty"`
-	Antonyms   string    `json:"antonyms,omitempty"`
-	Synonyms   string    `json:"synonyms,omitempty"`
-	AuthorId   uuid.UUID `json:"authorId,omitempty"`
-	Occurence  int       `json:"occurence,omitempty"`
+	Id             uuid.UUID      `json:"id,omitempty"`
+	Word           string         `json:"word,omitempty"`
+	ImageUrl       string         `json:"imageUrl,omitempty"`
+	AudioUrl       string         `json:"audioUrl,omitempty"`
+	Definition     string         `json:"definition,omitempty"`
+	Sentence       string         `json:"sentence,omitempty"`
+	Antonyms       string         `json:"antonyms,omitempty"`
+	Synonyms       string         `json:"synonyms,omitempty"`
+	SynonymList    []*RelatedWord `json:"synonymList,omitempty"`
+	AntonymList    []*RelatedWord `json:"antonymList,omitempty"`
+	Senses         []*CardSense   `json:"senses,omitempty"`
+	Pronunciation  string         `json:"pronunciation,omitempty"`
+	Gender         string         `json:"gender,omitempty"`
+	Plural         string         `json:"plural,omitempty"`
+	AuthorId       uuid.UUID      `json:"authorId,omitempty"`
+	SourceLanguage string         `json:"sourceLanguage,omitempty"`
+	TargetLanguage string         `json:"targetLanguage,omitempty"`
+	Level          string         `json:"level,omitempty"`
+	Occurence      int            `json:"occurence,omitempty"`
+	// Note and Mnemonic are the private annotations of the user searching
+	Note     string `json:"note,omitempty"`
+	Mnemonic string `json:"mnemonic,omitempty"`
+	Likes    uint32 `json:"likes"`
+	Dislikes uint32 `json:"dislikes"`
 }
 
 type CardForUserPagination struct {
@@ -34,15 +69,35 @@ type CardForUserPagination struct {
 }
 
 type CardForUser struct {
-	Id         uuid.UUID `json:"id,omitempty"`
-	Word       string    `json:"word,omitempty"`
-	ImageUrl   string    `json:"imageUrl,omitempty"`
-	Definition string    `json:"definition,omitempty"`
-	Sentence   string    `json:"sentence,omitempty"`
-	Antonyms   string    `json:"antonyms,omitempty"`
-	Synonyms   string    `json:"synonyms,omitempty"`
-	Status     string    `json:"status,omitempty"`
-	AuthorId   uuid.UUID `json:"authorId,omitempty"`
+	Id             uuid.UUID      `json:"id,omitempty"`
+	Word           string         `json:"word,omitempty"`
+	ImageUrl       string         `json:"imageUrl,omitempty"`
+	AudioUrl       string         `json:"audioUrl,omitempty"`
+	Definition     string         `json:"definition,omitempty"`
+	Sentence       string         `json:"sentence,omitempty"`
+	Antonyms       string         `json:"antonyms,omitempty"`
+	Synonyms       string         `json:"synonyms,omitempty"`
+	SynonymList    []*RelatedWord `json:"synonymList,omitempty"`
+	AntonymList    []*RelatedWord `json:"antonymList,omitempty"`
+	Senses         []*CardSense   `json:"senses,omitempty"`
+	Pronunciation  string         `json:"pronunciation,omitempty"`
+	Gender         string         `json:"gender,omitempty"`
+	Plural         string         `json:"plural,omitempty"`
+	Status         string         `json:"status,omitempty"`
+	AuthorId       uuid.UUID      `json:"authorId,omitempty"`
+	SourceLanguage string         `json:"sourceLanguage,omitempty"`
+	TargetLanguage string         `json:"targetLanguage,omitempty"`
+	Level          string         `json:"level,omitempty"`
+	Likes          uint32         `json:"likes"`
+	Dislikes       uint32         `json:"dislikes"`
+	// Liked and Disliked are the interactions of the user viewing the card
+	Liked    bool `json:"liked"`
+	Disliked bool `json:"disliked"`
+	// Note and Mnemonic are the private annotations of the user viewing the card
+	Note     string `json:"note,omitempty"`
+	Mnemonic string `json:"mnemonic,omitempty"`
+	// TopMnemonics are the best voted mnemonics published for the card
+	TopMnemonics []*CardMnemonic `json:"topMnemonics,omitempty"`
 }
 
 type CardUpdateType string
@@ -54,17 +109,64 @@ const (
 )
 
 type CardUpdate struct {
-	Id         uuid.UUID      `json:"id,omitempty"`
-	Word       string         `json:"word,omitempty"`
-	ImageUrl   string         `json:"imageUrl,omitempty"`
-	Definition string         `json:"definition,omitempty"`
-	Sentence   string         `json:"sentence,omitempty"`
-	Antonyms   string         `json:"antonyms,omitempty"`
-	Synonyms   string         `json:"synonyms,omitempty"`
-	Action     CardUpdateType `json:"action,omitempty"`
+	Id         uuid.UUID `json:"id,omitempty"`
+	Word       string    `json:"word,omitempty"`
+	ImageUrl   string    `json:"imageUrl,omitempty"`
+	AudioUrl   string    `json:"audioUrl,omitempty"`
+	Definition string    `json:"definition,omitempty"`
+	Sentence   string    `json:"sentence,omitempty"`
+	Antonyms   string    `json:"antonyms,omitempty"`
+	Synonyms   string    `json:"synonyms,omitempty"`
+	// SynonymList and AntonymList nil are read from Synonyms and Antonyms, keeping the links of unchanged words
+	SynonymList []*RelatedWord `json:"synonymList,omitempty"`
+	AntonymList []*RelatedWord `json:"antonymList,omitempty"`
+	// Senses nil keeps the senses of the card unless the definition or sentence is changed
+	Senses        []*CardSense `json:"senses,omitempty"`
+	Pronunciation string       `json:"pronunciation,omitempty"`
+	Gender        string       `json:"gender,omitempty"`
+	Plural        string       `json:"plural,omitempty"`
+	// Overrides the collection languages for this card
+	SourceLanguage string         `json:"sourceLanguage,omitempty"`
+	TargetLanguage string         `json:"targetLanguage,omitempty"`
+	Level          string         `json:"level,omitempty"`
+	Action         CardUpdateType `json:"action,omitempty"`
 }
 
 type CardSearch struct {
 	UserCards   []*CardWithOccurence `json:"userCards,omitempty"`
 	GlobalCards []*CardWithOccurence `json:"globalCards,omitempty"`
 }
+
+// CardRevision is the content a card had before one of its edits
+type CardRevision struct {
+	Id     uuid.UUID `json:"id"`
+	CardId uuid.UUID `json:"cardId"`
+	// Revision numbers the edits of the card starting from 1, the first revision is the original content
+	Revision       int            `json:"revision"`
+	Word           string         `json:"word,omitempty"`
+	ImageUrl       string         `json:"imageUrl,omitempty"`
+	AudioUrl       string         `json:"audioUrl,omitempty"`
+	Definition     string         `json:"definition,omitempty"`
+	Sentence       string         `json:"sentence,omitempty"`
+	Antonyms       string         `json:"antonyms,omitempty"`
+	Synonyms       string         `json:"synonyms,omitempty"`
+	SynonymList    []*RelatedWord `json:"synonymList,omitempty"`
+	AntonymList    []*RelatedWord `json:"antonymList,omitempty"`
+	Senses         []*CardSense   `json:"senses,omitempty"`
+	Pronunciation  string         `json:"pronunciation,omitempty"`
+	Gender         string         `json:"gender,omitempty"`
+	Plural         string         `json:"plural,omitempty"`
+	SourceLanguage string         `json:"sourceLanguage,omitempty"`
+	TargetLanguage string         `json:"targetLanguage,omitempty"`
+	Level          string         `json:"level,omitempty"`
+	EditorId       uuid.UUID      `json:"editorId"`
+	// EditedAt is when this content was replaced
+	EditedAt time.Time `json:"editedAt"`
+}
+
+// CardAudioGeneration reports the cards that got generated audio
+type CardAudioGeneration struct {
+	Generated int `json:"generated"`
+	// Failed are the words of the cards the audio could not be generated for
+	Failed []string `json:"failed"`
+}
@@ -10,3 +10,20 @@ type CardMetrics struct {
 	Likes    uint32    `json:"likes,omitempty"`
 	Dislikes uint32    `json:"dislikes,omitempty"`
 }
+
+type CardUserMetrics struct {
+	Id       uuid.UUID `json:"id,omitempty"`
+	UserId   uuid.UUID `json:"userId"`
+	CardId   uuid.UUID `json:"cardId"`
+	Liked    bool      `json:"liked"`
+	Disliked bool      `json:"disliked"`
+}
+
+type CardFullUserMetricsResponse struct {
+	CardId   uuid.UUID `json:"cardId,omitempty"`
+	Likes    uint32    `json:"likes"`
+	Dislikes uint32    `json:"dislikes"`
+	UserId   uuid.UUID `json:"userId"`
+	Liked    bool      `json:"liked"`
+	Disliked bool      `json:"disliked"`
+}
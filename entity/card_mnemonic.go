@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardMnemonic is a mnemonic published by a learner for everyone studying the card
+type CardMnemonic struct {
+	Id        uuid.UUID `json:"id"`
+	CardId    uuid.UUID `json:"cardId"`
+	AuthorId  uuid.UUID `json:"authorId"`
+	Text      string    `json:"text"`
+	Upvotes   uint32    `json:"upvotes"`
+	Downvotes uint32    `json:"downvotes"`
+	// Vote of the user viewing the mnemonic, 1 for an upvote, -1 for a downvote and 0 without a vote
+	Vote      int       `json:"vote"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type PublishCardMnemonicRequest struct {
+	Text string `json:"text"`
+}
+
+type CardMnemonicPagination struct {
+	Mnemonics []*CardMnemonic `json:"mnemonics"`
+	Page      int             `json:"page,omitempty"`
+	Size      int             `json:"size,omitempty"`
+	Total     int             `json:"total,omitempty"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardNote is the private note and mnemonic of a user on a card, any card can be annotated
+type CardNote struct {
+	Id        uuid.UUID `json:"id"`
+	CardId    uuid.UUID `json:"cardId"`
+	UserId    uuid.UUID `json:"userId"`
+	Note      string    `json:"note"`
+	Mnemonic  string    `json:"mnemonic"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type SaveCardNoteRequest struct {
+	Note     string `json:"note"`
+	Mnemonic string `json:"mnemonic"`
+}
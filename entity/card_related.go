@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CardRelation is how a related word relates to the card word
+type CardRelation string
+
+const (
+	CardRelation_Synonym CardRelation = "synonym"
+	CardRelation_Antonym CardRelation = "antonym"
+)
+
+const (
+	relatedWordsMaxAmount = 30
+	relatedWordMaxLen     = 100
+)
+
+// RelatedWord is a synonym or antonym of the card word, linked to the card of that word when it has one
+type RelatedWord struct {
+	Word   string     `json:"word"`
+	CardId *uuid.UUID `json:"cardId,omitempty"`
+}
+
+// ParseRelatedWords splits the free text synonyms or antonyms of old clients into words.
+// The words that are in known keep their card link.
+func ParseRelatedWords(text string, known []*RelatedWord) []*RelatedWord {
+	links := map[string]*uuid.UUID{}
+	for _, word := range known {
+		links[strings.ToLower(word.Word)] = word.CardId
+	}
+	words := []*RelatedWord{}
+	seen := map[string]bool{}
+	for _, value := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		value = strings.TrimSpace(value)
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		words = append(words, &RelatedWord{Word: value, CardId: links[key]})
+	}
+	return words
+}
+
+// RelatedWordsSummary is the flat synonyms or antonyms of the card for old clients
+func RelatedWordsSummary(words []*RelatedWord) string {
+	values := []string{}
+	for _, word := range words {
+		values = append(values, word.Word)
+	}
+	return strings.Join(values, ", ")
+}
+
+// normalizeRelatedWords trims the words and drops the empty and repeated ones, nil is kept
+func normalizeRelatedWords(words []*RelatedWord) ([]*RelatedWord, error) {
+	if words == nil {
+		return nil, nil
+	}
+	normalized := []*RelatedWord{}
+	seen := map[string]bool{}
+	for _, word := range words {
+		if word == nil {
+			continue
+		}
+		value := strings.TrimSpace(word.Word)
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		if len(value) > relatedWordMaxLen {
+			return nil, fmt.Errorf("%w: a related word is longer than %d characters", ErrInvalidCardBody, relatedWordMaxLen)
+		}
+		seen[key] = true
+		normalized = append(normalized, &RelatedWord{Word: value, CardId: word.CardId})
+	}
+	if len(normalized) > relatedWordsMaxAmount {
+		return nil, fmt.Errorf("%w: more than %d synonyms or antonyms", ErrInvalidCardBody, relatedWordsMaxAmount)
+	}
+	return normalized, nil
+}
+
+// normalizeCardRelatedWords validates the synonym and antonym lists of the card and sets the flat
+// Synonyms and Antonyms to their summary. A card without lists keeps its flat text, the lists are read from it.
+func normalizeCardRelatedWords(card *Card) error {
+	var err error
+	card.SynonymList, err = normalizeRelatedWords(card.SynonymList)
+	if err != nil {
+		return err
+	}
+	card.AntonymList, err = normalizeRelatedWords(card.AntonymList)
+	if err != nil {
+		return err
+	}
+	if card.SynonymList != nil {
+		card.Synonyms = RelatedWordsSummary(card.SynonymList)
+	}
+	if card.AntonymList != nil {
+		card.Antonyms = RelatedWordsSummary(card.AntonymList)
+	}
+	return nil
+}
+
+// SameRelatedWords tells whether both lists have the same words with the same links in the same order
+func SameRelatedWords(a, b []*RelatedWord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Word != b[i].Word {
+			return false
+		}
+		if (a[i].CardId == nil) != (b[i].CardId == nil) || (a[i].CardId != nil && *a[i].CardId != *b[i].CardId) {
+			return false
+		}
+	}
+	return true
+}
+
+// CardRelatedGraph is the graph of the words related to a card, following the links between cards
+type CardRelatedGraph struct {
+	CardId uuid.UUID          `json:"cardId"`
+	Nodes  []*CardRelatedNode `json:"nodes"`
+	Edges  []*CardRelatedEdge `json:"edges"`
+}
+
+// CardRelatedNode is a card or a related word that is not linked to a card
+type CardRelatedNode struct {
+	// Key is the card id, or the lower-cased word prefixed with "word:" for words without a card
+	Key        string     `json:"key"`
+	CardId     *uuid.UUID `json:"cardId,omitempty"`
+	Word       string     `json:"word"`
+	Definition string     `json:"definition,omitempty"`
+	ImageUrl   string     `json:"imageUrl,omitempty"`
+	// Depth is the number of relations between the node and the card of the graph
+	Depth int `json:"depth"`
+}
+
+// CardRelatedEdge goes from the card listing the related word to that word
+type CardRelatedEdge struct {
+	From     string       `json:"from"`
+	To       string       `json:"to"`
+	Relation CardRelation `json:"relation"`
+}
@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PartOfSpeech is the word class of a card sense
+type PartOfSpeech string
+
+const (
+	PartOfSpeech_Noun         PartOfSpeech = "noun"
+	PartOfSpeech_Verb         PartOfSpeech = "verb"
+	PartOfSpeech_Adjective    PartOfSpeech = "adjective"
+	PartOfSpeech_Adverb       PartOfSpeech = "adverb"
+	PartOfSpeech_Pronoun      PartOfSpeech = "pronoun"
+	PartOfSpeech_Preposition  PartOfSpeech = "preposition"
+	PartOfSpeech_Conjunction  PartOfSpeech = "conjunction"
+	PartOfSpeech_Interjection PartOfSpeech = "interjection"
+	PartOfSpeech_Determiner   PartOfSpeech = "determiner"
+	PartOfSpeech_Numeral      PartOfSpeech = "numeral"
+	PartOfSpeech_Particle     PartOfSpeech = "particle"
+	PartOfSpeech_Phrase       PartOfSpeech = "phrase"
+)
+
+var PartsOfSpeech = []PartOfSpeech{
+	PartOfSpeech_Noun,
+	PartOfSpeech_Verb,
+	PartOfSpeech_Adjective,
+	PartOfSpeech_Adverb,
+	PartOfSpeech_Pronoun,
+	PartOfSpeech_Preposition,
+	PartOfSpeech_Conjunction,
+	PartOfSpeech_Interjection,
+	PartOfSpeech_Determiner,
+	PartOfSpeech_Numeral,
+	PartOfSpeech_Particle,
+	PartOfSpeech_Phrase,
+}
+
+// Dictionary abbreviations accepted for the parts of speech
+var partOfSpeechAbbreviations = map[string]PartOfSpeech{
+	"n":      PartOfSpeech_Noun,
+	"v":      PartOfSpeech_Verb,
+	"adj":    PartOfSpeech_Adjective,
+	"adv":    PartOfSpeech_Adverb,
+	"pron":   PartOfSpeech_Pronoun,
+	"prep":   PartOfSpeech_Preposition,
+	"conj":   PartOfSpeech_Conjunction,
+	"interj": PartOfSpeech_Interjection,
+	"det":    PartOfSpeech_Determiner,
+	"num":    PartOfSpeech_Numeral,
+	"part":   PartOfSpeech_Particle,
+}
+
+const (
+	cardSensesMaxAmount   = 20
+	senseExamplesMaxCount = 10
+	pronunciationMaxLen   = 150
+	genderMaxLen          = 30
+	pluralMaxLen          = 150
+)
+
+var ErrInvalidPartOfSpeech = errors.New("unknown part of speech")
+var ErrInvalidCardBody = errors.New("invalid card body")
+
+// NormalizePartOfSpeech lower-cases the part of speech and expands abbreviations such as "adj".
+// An empty part of speech means it is not specified.
+func NormalizePartOfSpeech(partOfSpeech string) (PartOfSpeech, error) {
+	value := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(partOfSpeech)), ".")
+	if value == "" {
+		return "", nil
+	}
+	if abbreviated, ok := partOfSpeechAbbreviations[value]; ok {
+		return abbreviated, nil
+	}
+	for _, known := range PartsOfSpeech {
+		if PartOfSpeech(value) == known {
+			return known, nil
+		}
+	}
+	return "", ErrInvalidPartOfSpeech
+}
+
+// CardSense is one meaning of the card word
+type CardSense struct {
+	PartOfSpeech PartOfSpeech `json:"partOfSpeech,omitempty"`
+	Definition   string       `json:"definition"`
+	Examples     []string     `json:"examples,omitempty"`
+}
+
+// NewCardSenses returns the single sense described by the flat card fields,
+// nil when there is no part of speech as the flat fields already say everything
+func NewCardSenses(partOfSpeech, definition, sentence string) []*CardSense {
+	if strings.TrimSpace(partOfSpeech) == "" {
+		return nil
+	}
+	sense := &CardSense{PartOfSpeech: PartOfSpeech(partOfSpeech), Definition: definition}
+	if sentence != "" {
+		sense.Examples = []string{sentence}
+	}
+	return []*CardSense{sense}
+}
+
+// NormalizeCardBody validates the senses, the related words and the pronunciation of the card and sets the flat
+// Definition, Sentence, Synonyms and Antonyms to their summary, old clients only read the flat fields
+func NormalizeCardBody(card *Card) error {
+	if err := normalizeCardRelatedWords(card); err != nil {
+		return err
+	}
+	card.Pronunciation = strings.TrimSpace(card.Pronunciation)
+	card.Gender = strings.ToLower(strings.TrimSpace(card.Gender))
+	card.Plural = strings.TrimSpace(card.Plural)
+	if len(card.Pronunciation) > pronunciationMaxLen || len(card.Gender) > genderMaxLen || len(card.Plural) > pluralMaxLen {
+		return fmt.Errorf("%w: pronunciation, gender or plural is too long", ErrInvalidCardBody)
+	}
+
+	senses := []*CardSense{}
+	for _, sense := range card.Senses {
+		if sense == nil {
+			continue
+		}
+		partOfSpeech, err := NormalizePartOfSpeech(string(sense.PartOfSpeech))
+		if err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidPartOfSpeech, sense.PartOfSpeech)
+		}
+		normalized := &CardSense{PartOfSpeech: partOfSpeech, Definition: strings.TrimSpace(sense.Definition)}
+		for _, example := range sense.Examples {
+			if example = strings.TrimSpace(example); example != "" {
+				normalized.Examples = append(normalized.Examples, example)
+			}
+		}
+		if normalized.Definition == "" && len(normalized.Examples) == 0 {
+			continue
+		}
+		if len(normalized.Examples) > senseExamplesMaxCount {
+			return fmt.Errorf("%w: a sense has more than %d examples", ErrInvalidCardBody, senseExamplesMaxCount)
+		}
+		senses = append(senses, normalized)
+	}
+	if len(senses) > cardSensesMaxAmount {
+		return fmt.Errorf("%w: more than %d senses", ErrInvalidCardBody, cardSensesMaxAmount)
+	}
+	if len(senses) == 0 {
+		// An empty list removes the senses of an edited card, nil keeps them
+		if card.Senses != nil {
+			card.Senses = []*CardSense{}
+		}
+		return nil
+	}
+	card.Senses = senses
+	card.Definition = SensesSummary(senses)
+	for _, sense := range senses {
+		if len(sense.Examples) > 0 {
+			card.Sentence = sense.Examples[0]
+			break
+		}
+	}
+	return nil
+}
+
+// SensesSummary is the flat definition of a card with senses, the senses are numbered when there are several
+func SensesSummary(senses []*CardSense) string {
+	definitions := []string{}
+	for _, sense := range senses {
+		if sense.Definition != "" {
+			definitions = append(definitions, sense.Definition)
+		}
+	}
+	if len(definitions) == 1 {
+		return definitions[0]
+	}
+	for i, definition := range definitions {
+		definitions[i] = fmt.Sprintf("%d. %s", i+1, definition)
+	}
+	return strings.Join(definitions, " ")
+}
+
+// SameSenses tells whether both cards have the same senses in the same order
+func SameSenses(a, b []*CardSense) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].PartOfSpeech != b[i].PartOfSpeech ||
+			a[i].Definition != b[i].Definition ||
+			len(a[i].Examples) != len(b[i].Examples) {
+			return false
+		}
+		for j := range a[i].Examples {
+			if a[i].Examples[j] != b[i].Examples[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -11,10 +11,15 @@ type UserCollectionResponse struct {
 	Name             string    `json:"name"`
 	AuthorName       string    `json:"authorName"`
 	Topics           []string  `json:"topics"`
+	SourceLanguage   string    `json:"sourceLanguage,omitempty"`
+	TargetLanguage   string    `json:"targetLanguage,omitempty"`
+	Level            string    `json:"level,omitempty"`
 	Starred          bool      `json:"starred"`
 	Likes            uint32    `json:"likes"`
 	Dislikes         uint32    `json:"dislikes"`
 	Views            uint32    `json:"views"`
+	AverageRating    float64   `json:"averageRating"`
+	Ratings          uint32    `json:"ratings"`
 	Mastered         uint32    `json:"mastered"`
 	Reviewing        uint32    `json:"reviewing"`
 	Learning         uint32    `json:"learning"`
@@ -23,6 +28,7 @@ type UserCollectionResponse struct {
 	IsViewedByUser   bool      `json:"isViewedByUser"`
 	TotalCards       int       `json:"totalCards"`
 	CreatedDate      string    `json:"createdDate"`
+	Reason           string    `json:"reason,omitempty"`
 }
 
 type CollectionPreviewResponse struct {
@@ -40,40 +46,66 @@ type CollectionPreviewResponse struct {
 }
 
 type Collection struct {
-	Id        uuid.UUID  `json:"id,omitempty"`
-	Name      string     `json:"name,omitempty"`
-	Topics    []string   `json:"topics,omitempty"`
-	AuthorId  uuid.UUID  `json:"authorId,omitempty"`
-	CreatedAt time.Time  `json:"createdAt,omitempty"`
-	UpdatedAt time.Time  `json:"updatedAt,omitempty"`
-	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	Id       uuid.UUID `json:"id,omitempty"`
+	Name     string    `json:"name,omitempty"`
+	Topics   []string  `json:"topics,omitempty"`
+	AuthorId uuid.UUID `json:"authorId,omitempty"`
+	// BCP-47 tags of the word (source) and definition (target) languages
+	SourceLanguage string     `json:"sourceLanguage,omitempty"`
+	TargetLanguage string     `json:"targetLanguage,omitempty"`
+	Level          string     `json:"level,omitempty"`
+	Hidden         bool       `json:"hidden,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt,omitempty"`
+	UpdatedAt      time.Time  `json:"updatedAt,omitempty"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty"`
 }
 
 type CreateCollectionRequest struct {
-	Name   string   `json:"name,omitempty"`
-	Topics []string `json:"topics,omitempty"`
-	Cards  []*Card  `json:"cards,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Topics         []string `json:"topics,omitempty"`
+	SourceLanguage string   `json:"sourceLanguage,omitempty"`
+	TargetLanguage string   `json:"targetLanguage,omitempty"`
+	Level          string   `json:"level,omitempty"`
+	Cards          []*Card  `json:"cards,omitempty"`
+	// Duplicates is the duplicate policy, keep when empty
+	Duplicates DuplicatePolicy `json:"duplicates,omitempty"`
 }
 
 type GetCollectionWithCardsResponse struct {
-	Id         uuid.UUID      `json:"id,omitempty"`
-	Name       string         `json:"name,omitempty"`
-	Mastered   uint32         `json:"mastered"`
-	Reviewing  uint32         `json:"reviewing"`
-	Learning   uint32         `json:"learning"`
-	TotalCards int            `json:"totalCards,omitempty"`
-	Topics     []string       `json:"topics,omitempty"`
-	Cards      []*CardForUser `json:"cards,omitempty"`
+	Id             uuid.UUID `json:"id,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	Mastered       uint32    `json:"mastered"`
+	Reviewing      uint32    `json:"reviewing"`
+	Learning       uint32    `json:"learning"`
+	TotalCards     int       `json:"totalCards,omitempty"`
+	Topics         []string  `json:"topics,omitempty"`
+	SourceLanguage string    `json:"sourceLanguage,omitempty"`
+	TargetLanguage string    `json:"targetLanguage,omitempty"`
+	Level          string    `json:"level,omitempty"`
+	// SuggestedLevel is derived from the levels of the collection cards
+	SuggestedLevel string         `json:"suggestedLevel,omitempty"`
+	Cards          []*CardForUser `json:"cards,omitempty"`
 }
 
 type CreateMultipleCollectionResponse struct {
-	Name        string `json:"name,omitempty"`
-	CardsAmount uint32 `json:"cardsAmount,omitempty"`
+	// Sheet is the workbook sheet the collection was imported from
+	Sheet       string             `json:"sheet,omitempty"`
+	Name        string             `json:"name,omitempty"`
+	CardsAmount uint32             `json:"cardsAmount,omitempty"`
+	Duplicates  []*ImportDuplicate `json:"duplicates,omitempty"`
+	// Error tells why the sheet was not imported, the other sheets of the file are imported anyway
+	Error string `json:"error,omitempty"`
 }
 
 type UpdateCollectionRequest struct {
-	Id     uuid.UUID     `json:"id"`
-	Name   string        `json:"name"`
-	Topics []string      `json:"topics"`
-	Cards  []*CardUpdate `json:"cards"`
+	Id             uuid.UUID     `json:"id"`
+	Name           string        `json:"name"`
+	Topics         []string      `json:"topics"`
+	SourceLanguage string        `json:"sourceLanguage"`
+	TargetLanguage string        `json:"targetLanguage"`
+	Level          string        `json:"level,omitempty"`
+	Cards          []*CardUpdate `json:"cards"`
+	// ForkSharedCards edits a copy of the cards that are also in collections of other authors,
+	// otherwise the edits show in those collections too
+	ForkSharedCards bool `json:"forkSharedCards"`
 }
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CollectionEventType string
+
+const (
+	CollectionEventType_Like CollectionEventType = "like"
+	CollectionEventType_View CollectionEventType = "view"
+)
+
+type CollectionEvent struct {
+	Id           uuid.UUID           `json:"id,omitempty"`
+	CollectionId uuid.UUID           `json:"collectionId"`
+	UserId       uuid.UUID           `json:"userId"`
+	Type         CollectionEventType `json:"type"`
+	CreatedAt    time.Time           `json:"createdAt"`
+}
+
+type TrendingWindow string
+
+const (
+	TrendingWindow_Day   TrendingWindow = "day"
+	TrendingWindow_Week  TrendingWindow = "week"
+	TrendingWindow_Month TrendingWindow = "month"
+)
+
+var TrendingWindows = []TrendingWindow{
+	TrendingWindow_Day,
+	TrendingWindow_Week,
+	TrendingWindow_Month,
+}
+
+func (w TrendingWindow) IsValid() bool {
+	return w.Duration() > 0
+}
+
+// Duration returns how far back events are taken into account for the window
+func (w TrendingWindow) Duration() time.Duration {
+	switch w {
+	case TrendingWindow_Day:
+		return time.Hour * 24
+	case TrendingWindow_Week:
+		return time.Hour * 24 * 7
+	case TrendingWindow_Month:
+		return time.Hour * 24 * 30
+	}
+	return 0
+}
+
+// HalfLife returns the age at which an event counts half as much as a fresh one
+func (w TrendingWindow) HalfLife() time.Duration {
+	return w.Duration() / 4
+}
@@ -17,11 +17,13 @@ type CollectionFullUserMetricsResponse struct {
 }
 
 type CollectionMetrics struct {
-	Id           uuid.UUID `json:"id,omitempty"`
-	CollectionId uuid.UUID `json:"collectionId"`
-	Likes        uint32    `json:"likes"`
-	Dislikes     uint32    `json:"dislikes"`
-	Views        uint32    `json:"views"`
+	Id            uuid.UUID `json:"id,omitempty"`
+	CollectionId  uuid.UUID `json:"collectionId"`
+	Likes         uint32    `json:"likes"`
+	Dislikes      uint32    `json:"dislikes"`
+	Views         uint32    `json:"views"`
+	Ratings       uint32    `json:"ratings"`
+	AverageRating float64   `json:"averageRating"`
 }
 
 type CollectionUserMetrics struct {
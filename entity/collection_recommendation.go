@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// CollectionEngagement describes how a user interacted with a single collection
+type CollectionEngagement struct {
+	CollectionId uuid.UUID `json:"collectionId"`
+	Name         string    `json:"name"`
+	Topics       []string  `json:"topics"`
+	Liked        bool      `json:"liked"`
+	Starred      bool      `json:"starred"`
+	Studied      bool      `json:"studied"`
+}
+
+// RecommendationQuery is what the collections recommended to a user are scored on
+type RecommendationQuery struct {
+	// TopicWeights weighs the lowercase topics of the collections the user engages with, from 0 to 1
+	TopicWeights map[string]float64
+	// SourceIds are the collections the user liked or starred, collections liked or starred
+	// by the same other users are co-engaged
+	SourceIds          []uuid.UUID
+	TopicWeight        float64
+	CoEngagementWeight float64
+	PopularityWeight   float64
+}
+
+// RecommendationCandidate is a collection scored for a user, the topic and co-engagement scores are from 0 to 1
+type RecommendationCandidate struct {
+	Collection        *Collection `json:"collection"`
+	AverageRating     float64     `json:"averageRating"`
+	TopicScore        float64     `json:"topicScore"`
+	CoEngagementScore float64     `json:"coEngagementScore"`
+	// BestSourceId is the collection of the user sharing the most users with the candidate
+	BestSourceId *uuid.UUID `json:"bestSourceId"`
+	Score        float64    `json:"score"`
+}
+
+type CollectionRecommendation struct {
+	Collection    *Collection `json:"collection"`
+	Score         float64     `json:"score"`
+	AverageRating float64     `json:"averageRating"`
+	Reason        string      `json:"reason"`
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CollectionReviewMinRating = 1
+	CollectionReviewMaxRating = 5
+)
+
+type CollectionSort string
+
+const (
+	CollectionSort_Relevance CollectionSort = "relevance"
+	CollectionSort_Rating    CollectionSort = "rating"
+)
+
+type CollectionReview struct {
+	Id           uuid.UUID  `json:"id,omitempty"`
+	CollectionId uuid.UUID  `json:"collectionId"`
+	UserId       uuid.UUID  `json:"userId"`
+	Username     string     `json:"username"`
+	Rating       int        `json:"rating"`
+	Text         string     `json:"text"`
+	Reply        string     `json:"reply,omitempty"`
+	RepliedAt    *time.Time `json:"repliedAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+type CollectionReviewRequest struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+type CollectionReviewReplyRequest struct {
+	Reply string `json:"reply"`
+}
+
+type CollectionReviewPagination struct {
+	Reviews       []*CollectionReview `json:"reviews"`
+	AverageRating float64             `json:"averageRating"`
+	Ratings       uint32              `json:"ratings"`
+	Page          int                 `json:"page,omitempty"`
+	Size          int                 `json:"size,omitempty"`
+	Total         int                 `json:"total,omitempty"`
+}
+
+func (s CollectionSort) IsValid() bool {
+	return s == CollectionSort_Relevance || s == CollectionSort_Rating
+}
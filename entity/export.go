@@ -0,0 +1,44 @@
+package entity
+
+type ExportFormat string
+
+const (
+	ExportFormat_Apkg ExportFormat = "apkg"
+	ExportFormat_Xlsx ExportFormat = "xlsx"
+	ExportFormat_Csv  ExportFormat = "csv"
+	ExportFormat_Pdf  ExportFormat = "pdf"
+)
+
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormat_Apkg || f == ExportFormat_Xlsx || f == ExportFormat_Csv || f == ExportFormat_Pdf
+}
+
+// PdfLayout is the page layout of the printable PDF export
+type PdfLayout string
+
+const (
+	// PdfLayout_Cards prints the fronts of the cards on one page and their backs on the next page,
+	// aligned for double-sided printing so every sheet can be cut into flashcards
+	PdfLayout_Cards PdfLayout = "cards"
+	// PdfLayout_List prints the cards one after another as a vocabulary list
+	PdfLayout_List PdfLayout = "list"
+)
+
+func (l PdfLayout) IsValid() bool {
+	return l == PdfLayout_Cards || l == PdfLayout_List
+}
+
+type ExportOptions struct {
+	Format ExportFormat
+	// IncludeProgress exports the learning progress of the user, only supported by apkg
+	IncludeProgress bool
+	PdfLayout       PdfLayout
+	CardsPerPage    int
+}
+
+// ExportFile is a generated file ready to be sent to the client
+type ExportFile struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+type Folder struct {
+	Id       uuid.UUID  `json:"id,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	ParentId *uuid.UUID `json:"parentId,omitempty"`
+	UserId   uuid.UUID  `json:"userId,omitempty"`
+}
+
+type FolderCollection struct {
+	FolderId     uuid.UUID `json:"folderId"`
+	CollectionId uuid.UUID `json:"collectionId"`
+	Name         string    `json:"name"`
+	TotalCards   int       `json:"totalCards"`
+	Mastered     uint32    `json:"mastered"`
+	Reviewing    uint32    `json:"reviewing"`
+	Learning     uint32    `json:"learning"`
+}
+
+type FolderTree struct {
+	Id          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	ParentId    *uuid.UUID          `json:"parentId,omitempty"`
+	TotalCards  int                 `json:"totalCards"`
+	Mastered    uint32              `json:"mastered"`
+	Reviewing   uint32              `json:"reviewing"`
+	Learning    uint32              `json:"learning"`
+	Collections []*FolderCollection `json:"collections"`
+	Children    []*FolderTree       `json:"children"`
+}
+
+type CreateFolderRequest struct {
+	Name     string     `json:"name"`
+	ParentId *uuid.UUID `json:"parentId"`
+}
+
+type RenameFolderRequest struct {
+	Name string `json:"name"`
+}
+
+type MoveFolderRequest struct {
+	ParentId *uuid.UUID `json:"parentId"`
+}
+
+type AssignCollectionToFolderRequest struct {
+	FolderId *uuid.UUID `json:"folderId"`
+}
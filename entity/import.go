@@ -0,0 +1,190 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// ImportSkip describes an imported item that did not become a card
+type ImportSkip struct {
+	// Source identifies the item in the imported file, e.g. "note 1650000000000"
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+}
+
+type ImportReport struct {
+	CollectionId uuid.UUID          `json:"collectionId"`
+	Name         string             `json:"name"`
+	CardsAmount  uint32             `json:"cardsAmount"`
+	Skipped      []*ImportSkip      `json:"skipped"`
+	Duplicates   []*ImportDuplicate `json:"duplicates"`
+	// Warnings describes imported items that became cards without some of their media
+	Warnings []*ImportSkip `json:"warnings,omitempty"`
+}
+
+// DuplicatePolicy decides what happens to an imported card with the same word and definition
+// as another imported card or as a card the user already authored
+type DuplicatePolicy string
+
+const (
+	// DuplicatePolicy_Keep creates the duplicate cards anyway
+	DuplicatePolicy_Keep DuplicatePolicy = "keep"
+	// DuplicatePolicy_Skip leaves the duplicate cards out
+	DuplicatePolicy_Skip DuplicatePolicy = "skip"
+	// DuplicatePolicy_Merge adds the existing card of the user to the collection instead of creating a new one
+	DuplicatePolicy_Merge DuplicatePolicy = "merge"
+)
+
+func (p DuplicatePolicy) IsValid() bool {
+	return p == DuplicatePolicy_Keep || p == DuplicatePolicy_Skip || p == DuplicatePolicy_Merge
+}
+
+type DuplicateAction string
+
+const (
+	DuplicateAction_Kept    DuplicateAction = "kept"
+	DuplicateAction_Skipped DuplicateAction = "skipped"
+	DuplicateAction_Merged  DuplicateAction = "merged"
+)
+
+// ImportDuplicate describes an imported card that duplicates another card
+type ImportDuplicate struct {
+	Word       string `json:"word"`
+	Definition string `json:"definition"`
+	// CardId is the existing card of the user it duplicates, empty for a duplicate within the import
+	CardId *uuid.UUID      `json:"cardId,omitempty"`
+	Action DuplicateAction `json:"action"`
+}
+
+// ImportColumn is a card field a column of an imported table can be mapped to
+type ImportColumn string
+
+const (
+	ImportColumn_Word           ImportColumn = "word"
+	ImportColumn_Definition     ImportColumn = "definition"
+	ImportColumn_Sentence       ImportColumn = "sentence"
+	ImportColumn_ImageUrl       ImportColumn = "image_url"
+	ImportColumn_Antonyms       ImportColumn = "antonyms"
+	ImportColumn_Synonyms       ImportColumn = "synonyms"
+	ImportColumn_Level          ImportColumn = "level"
+	ImportColumn_SourceLanguage ImportColumn = "source_language"
+	ImportColumn_TargetLanguage ImportColumn = "target_language"
+	ImportColumn_PartOfSpeech   ImportColumn = "part_of_speech"
+	ImportColumn_Pronunciation  ImportColumn = "pronunciation"
+	ImportColumn_Gender         ImportColumn = "gender"
+	ImportColumn_Plural         ImportColumn = "plural"
+	ImportColumn_AudioUrl       ImportColumn = "audio_url"
+)
+
+// ImportColumns is the column order of the upload template
+var ImportColumns = []ImportColumn{
+	ImportColumn_Word,
+	ImportColumn_Definition,
+	ImportColumn_Sentence,
+	ImportColumn_ImageUrl,
+	ImportColumn_Antonyms,
+	ImportColumn_Synonyms,
+	ImportColumn_Level,
+	ImportColumn_SourceLanguage,
+	ImportColumn_TargetLanguage,
+	ImportColumn_PartOfSpeech,
+	ImportColumn_Pronunciation,
+	ImportColumn_Gender,
+	ImportColumn_Plural,
+	ImportColumn_AudioUrl,
+}
+
+func (c ImportColumn) IsValid() bool {
+	for _, column := range ImportColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+type TableImportOptions struct {
+	// Mapping maps card fields to a header name or a zero-based column index,
+	// when empty the columns are detected from the header row
+	Mapping map[ImportColumn]string `json:"mapping"`
+	// HasHeader tells whether the first row is a header, detected when not set
+	HasHeader *bool `json:"hasHeader"`
+	// DryRun validates the rows without creating anything
+	DryRun     bool            `json:"dryRun"`
+	Duplicates DuplicatePolicy `json:"duplicates"`
+}
+
+type ImportRowResult struct {
+	// Row is the 1-based row number in the file
+	Row      int      `json:"row"`
+	Card     *Card    `json:"card,omitempty"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+type TableImportResult struct {
+	DryRun bool `json:"dryRun"`
+	// Columns is the zero-based column index used for every mapped card field
+	Columns   map[ImportColumn]int `json:"columns"`
+	Rows      []*ImportRowResult   `json:"rows"`
+	ValidRows int                  `json:"validRows"`
+	// Report describes the created collection, it is empty for a dry run
+	Report *ImportReport `json:"report,omitempty"`
+}
+
+type TextSeparator string
+
+const (
+	TextSeparator_Tab       TextSeparator = "tab"
+	TextSeparator_Dash      TextSeparator = "dash"
+	TextSeparator_Comma     TextSeparator = "comma"
+	TextSeparator_Semicolon TextSeparator = "semicolon"
+	TextSeparator_Newline   TextSeparator = "newline"
+	TextSeparator_BlankLine TextSeparator = "blank_line"
+	TextSeparator_Custom    TextSeparator = "custom"
+)
+
+// A dash separates terms only when surrounded by spaces so hyphenated words are kept
+var textSeparators = map[TextSeparator]string{
+	TextSeparator_Tab:       "\t",
+	TextSeparator_Dash:      " - ",
+	TextSeparator_Comma:     ",",
+	TextSeparator_Semicolon: ";",
+	TextSeparator_Newline:   "\n",
+	TextSeparator_BlankLine: "\n\n",
+}
+
+// Value returns the separator string, custom is the separator for TextSeparator_Custom
+func (s TextSeparator) Value(custom string) (string, bool) {
+	if s == TextSeparator_Custom {
+		return custom, custom != ""
+	}
+	value, ok := textSeparators[s]
+	return value, ok
+}
+
+type TextImportRequest struct {
+	Text                string        `json:"text"`
+	TermSeparator       TextSeparator `json:"termSeparator"`
+	CustomTermSeparator string        `json:"customTermSeparator,omitempty"`
+	RowSeparator        TextSeparator `json:"rowSeparator"`
+	CustomRowSeparator  string        `json:"customRowSeparator,omitempty"`
+	// Preview parses the text without creating anything
+	Preview bool `json:"preview"`
+	// CollectionId appends the cards to an existing collection of the user instead of creating one
+	CollectionId   *uuid.UUID `json:"collectionId,omitempty"`
+	Name           string     `json:"name"`
+	Topics         []string   `json:"topics"`
+	SourceLanguage string     `json:"sourceLanguage"`
+	TargetLanguage string     `json:"targetLanguage"`
+	Level          string     `json:"level,omitempty"`
+	// Duplicates is the duplicate policy, keep when empty
+	Duplicates DuplicatePolicy `json:"duplicates,omitempty"`
+}
+
+type TextImportResult struct {
+	Preview bool          `json:"preview"`
+	Cards   []*Card       `json:"cards"`
+	Skipped []*ImportSkip `json:"skipped"`
+	// Report describes the created or updated collection, it is empty for a preview
+	Report *ImportReport `json:"report,omitempty"`
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatus_Pending   ImportJobStatus = "pending"
+	ImportJobStatus_Running   ImportJobStatus = "running"
+	ImportJobStatus_Completed ImportJobStatus = "completed"
+	ImportJobStatus_Failed    ImportJobStatus = "failed"
+	ImportJobStatus_Cancelled ImportJobStatus = "cancelled"
+)
+
+func (s ImportJobStatus) IsFinished() bool {
+	return s == ImportJobStatus_Completed || s == ImportJobStatus_Failed || s == ImportJobStatus_Cancelled
+}
+
+// ImportJob is a collection file upload processed in the background
+type ImportJob struct {
+	Id       uuid.UUID       `json:"id"`
+	UserId   uuid.UUID       `json:"userId"`
+	Status   ImportJobStatus `json:"status"`
+	Filename string          `json:"filename"`
+	// DuplicatePolicy is applied to the cards of every imported sheet
+	DuplicatePolicy DuplicatePolicy `json:"duplicatePolicy"`
+	// File is the uploaded file, it is kept until the job is finished so the job can be resumed after a restart
+	File      []byte `json:"-"`
+	TotalRows int    `json:"totalRows"`
+	// ProcessedRows counts the card rows handled so far, FailedRows the ones among them that had a problem
+	ProcessedRows int      `json:"processedRows"`
+	FailedRows    int      `json:"failedRows"`
+	Errors        []string `json:"errors"`
+	// Results has an entry per imported sheet of the file
+	Results     []*CreateMultipleCollectionResponse `json:"results,omitempty"`
+	CreatedAt   time.Time                           `json:"createdAt"`
+	UpdatedAt   time.Time                           `json:"updatedAt"`
+	CompletedAt *time.Time                          `json:"completedAt,omitempty"`
+}
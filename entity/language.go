@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var ErrInvalidLanguage = errors.New("invalid language tag")
+
+// NormalizeLanguageTag validates a BCP-47 language tag and returns its canonical form, e.g. "en-us" becomes "en-US".
+// An empty tag means the language is not specified and is returned as is.
+func NormalizeLanguageTag(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", nil
+	}
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// SearchFilter narrows down collection and card listings, empty fields do not filter
+type SearchFilter struct {
+	SourceLanguage string `json:"sourceLanguage,omitempty"`
+	TargetLanguage string `json:"targetLanguage,omitempty"`
+	Level          string `json:"level,omitempty"`
+	// PartOfSpeech only filters card listings, a card matches when one of its senses has it
+	PartOfSpeech PartOfSpeech `json:"partOfSpeech,omitempty"`
+}
+
+// Normalize validates the filter, its errors wrap ErrInvalidLanguage, ErrInvalidLevel or ErrInvalidPartOfSpeech
+func (f *SearchFilter) Normalize() error {
+	var err error
+	f.SourceLanguage, err = NormalizeLanguageTag(f.SourceLanguage)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLanguage, err)
+	}
+	f.TargetLanguage, err = NormalizeLanguageTag(f.TargetLanguage)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLanguage, err)
+	}
+	f.Level, err = NormalizeLevel(f.Level)
+	if err != nil {
+		return err
+	}
+	f.PartOfSpeech, err = NormalizePartOfSpeech(string(f.PartOfSpeech))
+	return err
+}
+
+// SearchFilterErrors are the errors a usecase reports for an invalid language, level or part of speech of a search filter
+type SearchFilterErrors struct {
+	Language     error
+	Level        error
+	PartOfSpeech error
+}
+
+// Of maps an error of SearchFilter.Normalize to the matching error, nil stays nil
+func (e SearchFilterErrors) Of(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrInvalidLevel):
+		return e.Level
+	case errors.Is(err, ErrInvalidPartOfSpeech):
+		return e.PartOfSpeech
+	}
+	return e.Language
+}
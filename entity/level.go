@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// CEFR levels in ascending order of difficulty
+var CEFRLevels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+const levelMaxLength = 30
+
+var ErrInvalidLevel = errors.New("level is too long")
+
+// NormalizeLevel upper-cases CEFR levels and trims custom levels such as "HSK 3" or "N5".
+// An empty level means the difficulty is not specified.
+func NormalizeLevel(level string) (string, error) {
+	level = strings.TrimSpace(level)
+	if CEFRLevelIndex(level) >= 0 {
+		return strings.ToUpper(level), nil
+	}
+	if len(level) > levelMaxLength {
+		return "", ErrInvalidLevel
+	}
+	return level, nil
+}
+
+// CEFRLevelIndex returns the position of the level in CEFRLevels or -1 for custom levels
+func CEFRLevelIndex(level string) int {
+	for i, cefrLevel := range CEFRLevels {
+		if strings.EqualFold(level, cefrLevel) {
+			return i
+		}
+	}
+	return -1
+}
+
+type LevelCount struct {
+	Level string `json:"level"`
+	Count int    `json:"count"`
+}
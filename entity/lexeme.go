@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lexeme is the canonical word shared by the cards of every author for that word in a language
+type Lexeme struct {
+	Id uuid.UUID `json:"id"`
+	// Word is the normalized word, see NormalizeLexemeWord
+	Word string `json:"word"`
+	// Language is the primary language subtag of the cards, e.g. "en" for "en" and "en-US" cards
+	Language  string `json:"language"`
+	CardCount int    `json:"cardCount"`
+	// CanonicalCardId is the best rated card of the lexeme, suggested for reuse
+	CanonicalCardId *uuid.UUID `json:"canonicalCardId,omitempty"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// NormalizeLexemeWord is the form of the card word cards are clustered by
+func NormalizeLexemeWord(word string) string {
+	return strings.ToLower(strings.Join(strings.Fields(word), " "))
+}
+
+// LexemeLanguage is the primary subtag of a normalized language tag, cards without a language keep an empty one
+func LexemeLanguage(tag string) string {
+	return strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+}
+
+// LexemeClusterResult sums up a run of the job linking the cards to their lexemes
+type LexemeClusterResult struct {
+	LinkedCards    int `json:"linkedCards"`
+	CreatedLexemes int `json:"createdLexemes"`
+	// Lexemes is the number of lexemes whose card count and canonical card were refreshed
+	Lexemes int `json:"lexemes"`
+}
+
+// SimilarCards are the existing cards for a word, the canonical card of the lexeme first
+type SimilarCards struct {
+	Lexeme *Lexeme              `json:"lexeme,omitempty"`
+	Cards  []*CardWithOccurence `json:"cards"`
+}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportTargetType string
+
+const (
+	ReportTargetType_Collection ReportTargetType = "collection"
+	ReportTargetType_Card       ReportTargetType = "card"
+	ReportTargetType_Mnemonic   ReportTargetType = "mnemonic"
+)
+
+type ReportReason string
+
+const (
+	ReportReason_Spam          ReportReason = "spam"
+	ReportReason_Abusive       ReportReason = "abusive"
+	ReportReason_Copyright     ReportReason = "copyright"
+	ReportReason_Inappropriate ReportReason = "inappropriate"
+	ReportReason_Other         ReportReason = "other"
+)
+
+func (r ReportReason) IsValid() bool {
+	switch r {
+	case ReportReason_Spam, ReportReason_Abusive, ReportReason_Copyright, ReportReason_Inappropriate, ReportReason_Other:
+		return true
+	}
+	return false
+}
+
+type ReportStatus string
+
+const (
+	ReportStatus_Open     ReportStatus = "open"
+	ReportStatus_Resolved ReportStatus = "resolved"
+)
+
+type ModerationAction string
+
+const (
+	ModerationAction_Hide    ModerationAction = "hide"
+	ModerationAction_Delete  ModerationAction = "delete"
+	ModerationAction_Dismiss ModerationAction = "dismiss"
+	ModerationAction_Warn    ModerationAction = "warn"
+)
+
+func (a ModerationAction) IsValid() bool {
+	switch a {
+	case ModerationAction_Hide, ModerationAction_Delete, ModerationAction_Dismiss, ModerationAction_Warn:
+		return true
+	}
+	return false
+}
+
+type Report struct {
+	Id          uuid.UUID        `json:"id,omitempty"`
+	ReporterId  uuid.UUID        `json:"reporterId"`
+	TargetType  ReportTargetType `json:"targetType"`
+	TargetId    uuid.UUID        `json:"targetId"`
+	TargetName  string           `json:"targetName,omitempty"`
+	AuthorId    uuid.UUID        `json:"authorId"`
+	Reason      ReportReason     `json:"reason"`
+	Details     string           `json:"details"`
+	Status      ReportStatus     `json:"status"`
+	Action      ModerationAction `json:"action,omitempty"`
+	ModeratorId *uuid.UUID       `json:"moderatorId,omitempty"`
+	Note        string           `json:"note,omitempty"`
+	// Number of open reports against the same target
+	TargetReports int        `json:"targetReports,omitempty"`
+	ResolvedAt    *time.Time `json:"resolvedAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+type ReportRequest struct {
+	Reason  ReportReason `json:"reason"`
+	Details string       `json:"details"`
+}
+
+type ModerationActionRequest struct {
+	Action ModerationAction `json:"action"`
+	Note   string           `json:"note"`
+}
+
+type ReportPagination struct {
+	Reports []*Report `json:"reports"`
+	Page    int       `json:"page,omitempty"`
+	Size    int       `json:"size,omitempty"`
+	Total   int       `json:"total,omitempty"`
+}
+
+type UserWarning struct {
+	Id         uuid.UUID        `json:"id,omitempty"`
+	UserId     uuid.UUID        `json:"userId"`
+	ReportId   uuid.UUID        `json:"reportId"`
+	TargetType ReportTargetType `json:"targetType"`
+	TargetId   uuid.UUID        `json:"targetId"`
+	Reason     ReportReason     `json:"reason"`
+	Note       string           `json:"note"`
+	CreatedAt  time.Time        `json:"createdAt"`
+}
@@ -7,12 +7,20 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+type UserRole string
+
+const (
+	UserRole_User  UserRole = "user"
+	UserRole_Admin UserRole = "admin"
+)
+
 type User struct {
 	Id       uuid.UUID `json:"id,omitempty"`
 	Name     string    `json:"name,omitempty"`
 	Username string    `json:"username,omitempty"`
 	Email    string    `json:"email,omitempty"`
 	Password string    `json:"password,omitempty"`
+	Role     UserRole  `json:"role,omitempty"`
 }
 
 type ProfileInfoResp struct {
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserDataExportStatus string
+
+const (
+	UserDataExportStatus_Pending   UserDataExportStatus = "pending"
+	UserDataExportStatus_Running   UserDataExportStatus = "running"
+	UserDataExportStatus_Completed UserDataExportStatus = "completed"
+	UserDataExportStatus_Failed    UserDataExportStatus = "failed"
+)
+
+// UserDataExport is a request of a user for an archive with all of their personal data
+type UserDataExport struct {
+	Id     uuid.UUID            `json:"id"`
+	UserId uuid.UUID            `json:"userId"`
+	Status UserDataExportStatus `json:"status"`
+	// ObjectName is the location of the archive in the storage
+	ObjectName string `json:"-"`
+	Error      string `json:"error,omitempty"`
+	// DownloadUrl is a short lived link to the archive, it is set only for a completed export
+	DownloadUrl string     `json:"downloadUrl,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+type UserDataCard struct {
+	Card
+	// ImageFile is the path of the card image inside the archive
+	ImageFile string `json:"imageFile,omitempty"`
+}
+
+type UserDataCollection struct {
+	Collection
+	CardIds []uuid.UUID `json:"cardIds"`
+}
+
+type UserDataFolderCollection struct {
+	FolderId     uuid.UUID `json:"folderId"`
+	CollectionId uuid.UUID `json:"collectionId"`
+	Name         string    `json:"name"`
+}
+
+// UserDataArchive is the content of data.json in the personal data export archive
+type UserDataArchive struct {
+	ExportedAt         time.Time                   `json:"exportedAt"`
+	Profile            *User                       `json:"profile"`
+	Collections        []*UserDataCollection       `json:"collections"`
+	Cards              []*UserDataCard             `json:"cards"`
+	Folders            []*Folder                   `json:"folders"`
+	FolderCollections  []*UserDataFolderCollection `json:"folderCollections"`
+	CollectionMetrics  []*CollectionUserMetrics    `json:"collectionMetrics"`
+	CollectionProgress []*CollectionUserProgress   `json:"collectionProgress"`
+	CardProgress       []*CardUserProgress         `json:"cardProgress"`
+	CardNotes          []*CardNote                 `json:"cardNotes"`
+	CardMnemonics      []*CardMnemonic             `json:"cardMnemonics"`
+	CardMetrics        []*CardUserMetrics          `json:"cardMetrics"`
+}
@@ -20,11 +20,23 @@ type RestCollectionHandler interface {
 	StarCollectionById(c *gin.Context)
 	GetCollectionUserProgress(c *gin.Context)
 	UploadCollectionWithFile(c *gin.Context)
+	GetImportJob(c *gin.Context)
+	CancelImportJob(c *gin.Context)
+	ImportAnkiCollection(c *gin.Context)
+	ImportTable(c *gin.Context)
+	ImportText(c *gin.Context)
+	ExportCollection(c *gin.Context)
 	UpdateCollection(c *gin.Context)
+	GetTrendingCollections(c *gin.Context)
+	ReviewCollection(c *gin.Context)
+	DeleteCollectionReview(c *gin.Context)
+	ReplyToCollectionReview(c *gin.Context)
+	GetCollectionReviews(c *gin.Context)
 
 	UnregisteredGetRecommendedCollectionsPreview(c *gin.Context)
 	UnregisteredGetCollectionWithCards(c *gin.Context)
 	UnregisteredSearchCollectionByName(c *gin.Context)
+	UnregisteredGetCollectionReviews(c *gin.Context)
 }
 
 type RestUserHandler interface {
@@ -32,6 +44,17 @@ type RestUserHandler interface {
 	UsernameExists(c *gin.Context)
 	Login(c *gin.Context)
 	GetProfile(c *gin.Context)
+	RequestDataExport(c *gin.Context)
+	GetDataExport(c *gin.Context)
+}
+
+type RestFolderHandler interface {
+	GetFolderTree(c *gin.Context)
+	CreateFolder(c *gin.Context)
+	RenameFolder(c *gin.Context)
+	MoveFolder(c *gin.Context)
+	DeleteFolder(c *gin.Context)
+	MoveCollectionToFolder(c *gin.Context)
 }
 
 type RestCardHandler interface {
@@ -40,4 +63,32 @@ type RestCardHandler interface {
 	SearchByWord(c *gin.Context)
 	KnowCard(c *gin.Context)
 	DontKnowCard(c *gin.Context)
+	GetCardRevisions(c *gin.Context)
+	GetRelatedCards(c *gin.Context)
+	SaveCardNote(c *gin.Context)
+	DeleteCardNote(c *gin.Context)
+	PublishCardMnemonic(c *gin.Context)
+	GetCardMnemonics(c *gin.Context)
+	UpvoteCardMnemonic(c *gin.Context)
+	DownvoteCardMnemonic(c *gin.Context)
+	DeleteCardMnemonic(c *gin.Context)
+	LikeCardById(c *gin.Context)
+	DislikeCardById(c *gin.Context)
+	UploadCardAudio(c *gin.Context)
+	GenerateMissingAudio(c *gin.Context)
+}
+
+type RestModerationHandler interface {
+	ReportCollection(c *gin.Context)
+	ReportCard(c *gin.Context)
+	ReportMnemonic(c *gin.Context)
+	GetReports(c *gin.Context)
+	ModerateReport(c *gin.Context)
+	GetMyWarnings(c *gin.Context)
+}
+
+type RestLexemeHandler interface {
+	GetLexeme(c *gin.Context)
+	GetSimilarCards(c *gin.Context)
+	ClusterCards(c *gin.Context)
 }
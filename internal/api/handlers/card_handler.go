@@ -7,6 +7,7 @@ import (
 
 	cardUC "github.com/flash-cards-vocab/backend/app/usecase/card"
 	collectionUC "github.com/flash-cards-vocab/backend/app/usecase/collection"
+	"github.com/flash-cards-vocab/backend/entity"
 	handlerIntf "github.com/flash-cards-vocab/backend/internal/api/handler_interfaces"
 	"github.com/flash-cards-vocab/backend/pkg/helpers"
 
@@ -75,12 +76,19 @@ func (h *handlerCard) SearchByWord(c *gin.Context) {
 		size = 10
 	}
 
-	data, err := h.cardUsecase.SearchByWord(search, userCtx.UserId, page, size)
+	filter := searchFilterFromQuery(c)
+	filter.PartOfSpeech = entity.PartOfSpeech(c.Query("part_of_speech"))
+
+	data, err := h.cardUsecase.SearchByWord(search, userCtx.UserId, filter, page, size)
 	if err == nil {
 		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
 	} else {
 		if errors.Is(err, collectionUC.ErrNotFound) {
 			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, cardUC.ErrInvalidLanguage) ||
+			errors.Is(err, cardUC.ErrInvalidLevel) ||
+			errors.Is(err, cardUC.ErrInvalidPartOfSpeech) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
 		}
@@ -174,3 +182,325 @@ func (h *handlerCard) AddExistingCardToCollection(c *gin.Context) {
 		}
 	}
 }
+
+func (h *handlerCard) GetCardRevisions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	revisions, err := h.cardUsecase.GetCardRevisions(id, userCtx.UserId)
+	if err != nil {
+		if errors.Is(err, cardUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: revisions})
+}
+
+func (h *handlerCard) GetRelatedCards(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	depth := 1
+	if c.Query("depth") != "" {
+		depth, err = strconv.Atoi(c.Query("depth"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: cardUC.ErrInvalidDepth.Error()})
+			return
+		}
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	graph, err := h.cardUsecase.GetRelatedCards(id, userCtx.UserId, depth)
+	if err != nil {
+		if errors.Is(err, cardUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, cardUC.ErrInvalidDepth) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: graph})
+}
+
+func (h *handlerCard) LikeCardById(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	metrics, err := h.cardUsecase.LikeCardById(id, userCtx.UserId)
+	if err != nil {
+		if errors.Is(err, cardUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: metrics})
+}
+
+func (h *handlerCard) DislikeCardById(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	metrics, err := h.cardUsecase.DislikeCardById(id, userCtx.UserId)
+	if err != nil {
+		if errors.Is(err, cardUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: metrics})
+}
+
+func (h *handlerCard) UploadCardAudio(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	audioUrl, err := h.cardUsecase.UploadCardAudio(file, header.Filename)
+	if err != nil {
+		if errors.Is(err, cardUC.ErrInvalidAudio) || errors.Is(err, cardUC.ErrAudioTooLong) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: audioUrl})
+}
+
+func (h *handlerCard) GenerateMissingAudio(c *gin.Context) {
+	collectionId, err := uuid.Parse(c.Param("collection_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	result, err := h.cardUsecase.GenerateMissingAudio(collectionId, userCtx.UserId)
+	if err != nil {
+		if errors.Is(err, cardUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, cardUC.ErrUnauthorized) {
+			c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, cardUC.ErrSpeechUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: result})
+}
+
+func (h *handlerCard) SaveCardNote(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+	var request entity.SaveCardNoteRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	note, err := h.cardUsecase.SaveCardNote(id, userCtx.UserId, request)
+	if err != nil {
+		if errors.Is(err, cardUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, cardUC.ErrInvalidNote) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: note})
+}
+
+func (h *handlerCard) DeleteCardNote(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.cardUsecase.DeleteCardNote(id, userCtx.UserId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "Note deleted"})
+}
+
+func (h *handlerCard) PublishCardMnemonic(c *gin.Context) {
+	cardId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+	var request entity.PublishCardMnemonicRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	mnemonic, err := h.cardUsecase.PublishCardMnemonic(cardId, userCtx.UserId, request)
+	if err != nil {
+		mnemonicErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: mnemonic})
+}
+
+func (h *handlerCard) GetCardMnemonics(c *gin.Context) {
+	cardId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+	page, err := strconv.Atoi(c.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(c.Query("size"))
+	if err != nil || size < 1 {
+		size = 10
+	}
+
+	mnemonics, err := h.cardUsecase.GetCardMnemonics(cardId, userCtx.UserId, page, size)
+	if err != nil {
+		mnemonicErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: mnemonics})
+}
+
+func (h *handlerCard) UpvoteCardMnemonic(c *gin.Context) {
+	h.voteCardMnemonic(c, h.cardUsecase.UpvoteCardMnemonic)
+}
+
+func (h *handlerCard) DownvoteCardMnemonic(c *gin.Context) {
+	h.voteCardMnemonic(c, h.cardUsecase.DownvoteCardMnemonic)
+}
+
+func (h *handlerCard) voteCardMnemonic(c *gin.Context, vote func(id, userId uuid.UUID) (*entity.CardMnemonic, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	mnemonic, err := vote(id, userCtx.UserId)
+	if err != nil {
+		mnemonicErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: mnemonic})
+}
+
+func (h *handlerCard) DeleteCardMnemonic(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.cardUsecase.DeleteCardMnemonic(id, userCtx.UserId)
+	if err != nil {
+		mnemonicErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "Mnemonic deleted"})
+}
+
+func mnemonicErrorResponse(c *gin.Context, err error) {
+	if errors.Is(err, cardUC.ErrNotFound) {
+		c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, cardUC.ErrUnauthorized) || errors.Is(err, cardUC.ErrForbiddenSelfRequest) {
+		c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, cardUC.ErrInvalidMnemonic) || errors.Is(err, cardUC.ErrMnemonicAlreadyPublished) {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, cardUC.ErrMnemonicLimitReached) {
+		c.JSON(http.StatusTooManyRequests, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+	}
+}
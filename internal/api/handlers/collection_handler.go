@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
+	"mime"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	collectionUC "github.com/flash-cards-vocab/backend/app/usecase/collection"
 	"github.com/flash-cards-vocab/backend/entity"
@@ -75,13 +79,22 @@ func (h *handlerCollection) GetRecommendedCollectionsPreview(c *gin.Context) {
 	if err != nil || size < 1 {
 		size = 15
 	}
+	sort := entity.CollectionSort(c.DefaultQuery("sort", string(entity.CollectionSort_Relevance)))
+	if !sort.IsValid() {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: collectionUC.ErrInvalidSort.Error()})
+		return
+	}
 
-	data, err := h.collectionUsecase.GetRecommendedCollectionsPreview(userCtx.UserId, page, size)
+	data, err := h.collectionUsecase.GetRecommendedCollectionsPreview(userCtx.UserId, page, size, sort, searchFilterFromQuery(c))
 	if err == nil {
 		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
 	} else {
 		if errors.Is(err, collectionUC.ErrNotFound) {
 			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) ||
+			errors.Is(err, collectionUC.ErrInvalidPartOfSpeech) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
 		}
@@ -278,13 +291,22 @@ func (h *handlerCollection) SearchCollectionByName(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
 	}
 	text := c.Param("query")
+	sort := entity.CollectionSort(c.DefaultQuery("sort", string(entity.CollectionSort_Relevance)))
+	if !sort.IsValid() {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: collectionUC.ErrInvalidSort.Error()})
+		return
+	}
 
-	data, err := h.collectionUsecase.SearchCollectionByName(text, userCtx.UserId)
+	data, err := h.collectionUsecase.SearchCollectionByName(text, userCtx.UserId, sort, searchFilterFromQuery(c))
 	if err == nil {
 		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
 	} else {
 		if errors.Is(err, collectionUC.ErrNotFound) {
 			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) ||
+			errors.Is(err, collectionUC.ErrInvalidPartOfSpeech) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
 		}
@@ -303,16 +325,26 @@ func (h *handlerCollection) CreateCollection(c *gin.Context) {
 	}
 
 	collectionToCreate := entity.Collection{
-		Name:     createCollectionData.Name,
-		Topics:   createCollectionData.Topics,
-		AuthorId: userCtx.UserId,
-	}
-	err = h.collectionUsecase.CreateCollection(collectionToCreate, createCollectionData.Cards, userCtx.UserId)
+		Name:           createCollectionData.Name,
+		Topics:         createCollectionData.Topics,
+		AuthorId:       userCtx.UserId,
+		SourceLanguage: createCollectionData.SourceLanguage,
+		TargetLanguage: createCollectionData.TargetLanguage,
+		Level:          createCollectionData.Level,
+	}
+	err = h.collectionUsecase.CreateCollection(collectionToCreate, createCollectionData.Cards, userCtx.UserId, createCollectionData.Duplicates)
 	if err == nil {
 		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{"Collection Created"})
 	} else {
 		if errors.Is(err, collectionUC.ErrNotFound) {
 			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) ||
+			errors.Is(err, collectionUC.ErrInvalidPartOfSpeech) ||
+			errors.Is(err, collectionUC.ErrInvalidCardBody) ||
+			errors.Is(err, collectionUC.ErrInvalidAudio) ||
+			errors.Is(err, collectionUC.ErrInvalidDuplicatePolicy) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
 		}
@@ -340,7 +372,17 @@ func (h *handlerCollection) UploadCollectionWithFile(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.collectionUsecase.UploadCollectionWithFile(userCtx.UserId, file, handler.Filename)
+	duplicates := entity.DuplicatePolicy(c.PostForm("duplicates"))
+	job, err := h.collectionUsecase.UploadCollectionWithFile(userCtx.UserId, file, handler.Filename, duplicates)
+	if errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+		errors.Is(err, collectionUC.ErrInvalidLevel) ||
+		errors.Is(err, collectionUC.ErrInvalidPartOfSpeech) ||
+		errors.Is(err, collectionUC.ErrInvalidCardBody) ||
+		errors.Is(err, collectionUC.ErrInvalidDuplicatePolicy) ||
+		errors.Is(err, collectionUC.ErrInvalidImportFile) {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "here" + err.Error()})
 		return
@@ -348,8 +390,217 @@ func (h *handlerCollection) UploadCollectionWithFile(c *gin.Context) {
 
 	c.Request.Header.Set("Content-Type", "application/json")
 	// c.JSON(http.StatusOK, resp)
+	c.JSON(http.StatusAccepted, handlerIntf.SuccessResponse{Result: job})
+
+}
+
+func (h *handlerCollection) GetImportJob(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	job, err := h.collectionUsecase.GetImportJob(id, userCtx.UserId)
+	if err != nil {
+		if errors.Is(err, collectionUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrUnauthorized) {
+			c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: job})
+}
+
+func (h *handlerCollection) CancelImportJob(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	job, err := h.collectionUsecase.CancelImportJob(id, userCtx.UserId)
+	if err != nil {
+		if errors.Is(err, collectionUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrUnauthorized) {
+			c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrImportJobFinished) {
+			c.JSON(http.StatusConflict, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: job})
+}
+
+func (h *handlerCollection) ImportAnkiCollection(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	// The form fields and boundaries come on top of the package
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, collectionUC.AnkiPackageMaxSize+1<<20)
+	file, handler, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	collection := importedCollectionFromForm(c, handler.Filename)
+	duplicates := entity.DuplicatePolicy(c.PostForm("duplicates"))
+	resp, err := h.collectionUsecase.ImportAnkiCollection(userCtx.UserId, collection, file, handler.Size, duplicates)
+	if err != nil {
+		if errors.Is(err, collectionUC.ErrEmptyImport) ||
+			errors.Is(err, collectionUC.ErrInvalidDuplicatePolicy) ||
+			errors.Is(err, collectionUC.ErrInvalidAnkiPackage) ||
+			errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) ||
+			errors.Is(err, collectionUC.ErrInvalidCardBody) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
 	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: resp})
+}
+
+func (h *handlerCollection) ImportTable(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	file, handler, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	options := entity.TableImportOptions{
+		DryRun:     c.PostForm("dry_run") == "true",
+		Duplicates: entity.DuplicatePolicy(c.PostForm("duplicates")),
+	}
+	if mapping := c.PostForm("mapping"); mapping != "" {
+		err = json.Unmarshal([]byte(mapping), &options.Mapping)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: collectionUC.ErrInvalidColumnMapping.Error()})
+			return
+		}
+	}
+	if hasHeader, err := strconv.ParseBool(c.PostForm("has_header")); err == nil {
+		options.HasHeader = &hasHeader
+	}
 
+	collection := importedCollectionFromForm(c, handler.Filename)
+	resp, err := h.collectionUsecase.ImportTable(userCtx.UserId, collection, file, handler.Filename, options)
+	if err != nil {
+		if errors.Is(err, collectionUC.ErrEmptyImport) ||
+			errors.Is(err, collectionUC.ErrInvalidImportFile) ||
+			errors.Is(err, collectionUC.ErrInvalidColumnMapping) ||
+			errors.Is(err, collectionUC.ErrInvalidDuplicatePolicy) ||
+			errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: resp})
+}
+
+func (h *handlerCollection) ImportText(c *gin.Context) {
+	var request entity.TextImportRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	resp, err := h.collectionUsecase.ImportText(userCtx.UserId, request)
+	if err != nil {
+		if errors.Is(err, collectionUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrUnauthorized) {
+			c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrEmptyImport) ||
+			errors.Is(err, collectionUC.ErrInvalidSeparator) ||
+			errors.Is(err, collectionUC.ErrInvalidDuplicatePolicy) ||
+			errors.Is(err, collectionUC.ErrTooManyCards) ||
+			errors.Is(err, collectionUC.ErrEmptyCollectionName) ||
+			errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) ||
+			errors.Is(err, collectionUC.ErrInvalidCardBody) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: resp})
+}
+
+func (h *handlerCollection) ExportCollection(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	options := entity.ExportOptions{
+		Format:          entity.ExportFormat(c.DefaultQuery("format", string(entity.ExportFormat_Apkg))),
+		IncludeProgress: c.Query("include_progress") == "true",
+		PdfLayout:       entity.PdfLayout(c.DefaultQuery("layout", string(entity.PdfLayout_Cards))),
+	}
+	options.CardsPerPage, err = strconv.Atoi(c.DefaultQuery("cards_per_page", "8"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: collectionUC.ErrInvalidPdfOptions.Error()})
+		return
+	}
+
+	file, err := h.collectionUsecase.ExportCollection(id, userCtx.UserId, options)
+	if err != nil {
+		if errors.Is(err, collectionUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrInvalidExportFormat) || errors.Is(err, collectionUC.ErrInvalidPdfOptions) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Filename}))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
 }
 
 func (h *handlerCollection) UpdateCollection(c *gin.Context) {
@@ -369,6 +620,42 @@ func (h *handlerCollection) UpdateCollection(c *gin.Context) {
 	} else {
 		if errors.Is(err, collectionUC.ErrNotFound) {
 			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrUnauthorized) {
+			c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) ||
+			errors.Is(err, collectionUC.ErrInvalidPartOfSpeech) ||
+			errors.Is(err, collectionUC.ErrInvalidCardBody) ||
+			errors.Is(err, collectionUC.ErrInvalidAudio) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+	}
+}
+
+func (h *handlerCollection) GetTrendingCollections(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+	window := entity.TrendingWindow(c.DefaultQuery("window", string(entity.TrendingWindow_Week)))
+	page, err := strconv.Atoi(c.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(c.Query("size"))
+	if err != nil || size < 1 {
+		size = 15
+	}
+
+	data, err := h.collectionUsecase.GetTrendingCollections(userCtx.UserId, window, page, size)
+	if err == nil {
+		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+	} else {
+		if errors.Is(err, collectionUC.ErrInvalidTrendingWindow) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
 		}
@@ -384,13 +671,22 @@ func (h *handlerCollection) UnregisteredGetRecommendedCollectionsPreview(c *gin.
 	if err != nil || size < 1 {
 		size = 5
 	}
+	sort := entity.CollectionSort(c.DefaultQuery("sort", string(entity.CollectionSort_Relevance)))
+	if !sort.IsValid() {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: collectionUC.ErrInvalidSort.Error()})
+		return
+	}
 
-	data, err := h.collectionUsecase.GetRecommendedCollectionsPreviewForUnregistered(page, size)
+	data, err := h.collectionUsecase.GetRecommendedCollectionsPreviewForUnregistered(page, size, sort, searchFilterFromQuery(c))
 	if err == nil {
 		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
 	} else {
 		if errors.Is(err, collectionUC.ErrNotFound) {
 			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) ||
+			errors.Is(err, collectionUC.ErrInvalidPartOfSpeech) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
 		}
@@ -426,15 +722,174 @@ func (h *handlerCollection) UnregisteredGetCollectionWithCards(c *gin.Context) {
 
 func (h *handlerCollection) UnregisteredSearchCollectionByName(c *gin.Context) {
 	text := c.Param("query")
+	sort := entity.CollectionSort(c.DefaultQuery("sort", string(entity.CollectionSort_Relevance)))
+	if !sort.IsValid() {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: collectionUC.ErrInvalidSort.Error()})
+		return
+	}
 
-	data, err := h.collectionUsecase.SearchCollectionByNameForUnregistered(text)
+	data, err := h.collectionUsecase.SearchCollectionByNameForUnregistered(text, sort, searchFilterFromQuery(c))
 	if err == nil {
 		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
 	} else {
 		if errors.Is(err, collectionUC.ErrNotFound) {
 			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrInvalidLanguage) ||
+			errors.Is(err, collectionUC.ErrInvalidLevel) ||
+			errors.Is(err, collectionUC.ErrInvalidPartOfSpeech) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+	}
+}
+
+func (h *handlerCollection) ReviewCollection(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	var reviewData entity.CollectionReviewRequest
+	err = c.ShouldBindJSON(&reviewData)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	data, err := h.collectionUsecase.ReviewCollection(id, userCtx.UserId, reviewData)
+	if err == nil {
+		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+	} else {
+		if errors.Is(err, collectionUC.ErrInvalidRating) {
+			c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrForbiddenSelfRequest) {
+			c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+	}
+}
+
+func (h *handlerCollection) DeleteCollectionReview(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.collectionUsecase.DeleteCollectionReview(id, userCtx.UserId)
+	if err == nil {
+		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "success"})
+	} else {
+		if errors.Is(err, collectionUC.ErrReviewNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+	}
+}
+
+func (h *handlerCollection) ReplyToCollectionReview(c *gin.Context) {
+	reviewId, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	var replyData entity.CollectionReviewReplyRequest
+	err = c.ShouldBindJSON(&replyData)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.collectionUsecase.ReplyToCollectionReview(reviewId, userCtx.UserId, replyData.Reply)
+	if err == nil {
+		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "success"})
+	} else {
+		if errors.Is(err, collectionUC.ErrReviewNotFound) || errors.Is(err, collectionUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, collectionUC.ErrUnauthorized) {
+			c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
 		}
 	}
 }
+
+func (h *handlerCollection) GetCollectionReviews(c *gin.Context) {
+	h.getCollectionReviews(c)
+}
+
+func (h *handlerCollection) UnregisteredGetCollectionReviews(c *gin.Context) {
+	h.getCollectionReviews(c)
+}
+
+func (h *handlerCollection) getCollectionReviews(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	page, err := strconv.Atoi(c.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(c.Query("size"))
+	if err != nil || size < 1 {
+		size = 10
+	}
+
+	data, err := h.collectionUsecase.GetCollectionReviews(id, page, size)
+	if err == nil {
+		c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+	} else {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+	}
+}
+
+// importedCollectionFromForm reads the collection fields sent with an imported file,
+// the collection name defaults to the name of the file
+func importedCollectionFromForm(c *gin.Context, filename string) entity.Collection {
+	name := strings.TrimSpace(c.PostForm("name"))
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	topics := []string{}
+	if c.PostForm("topics") != "" {
+		topics = strings.Split(c.PostForm("topics"), ";")
+	}
+	return entity.Collection{
+		Name:           name,
+		Topics:         topics,
+		SourceLanguage: c.PostForm("source_language"),
+		TargetLanguage: c.PostForm("target_language"),
+		Level:          c.PostForm("level"),
+	}
+}
+
+// searchFilterFromQuery reads the optional listing filters shared by collection and card searches
+func searchFilterFromQuery(c *gin.Context) entity.SearchFilter {
+	return entity.SearchFilter{
+		SourceLanguage: c.Query("source_language"),
+		TargetLanguage: c.Query("target_language"),
+		Level:          c.Query("level"),
+	}
+}
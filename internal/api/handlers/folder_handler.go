@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	folderUC "github.com/flash-cards-vocab/backend/app/usecase/folder"
+	"github.com/flash-cards-vocab/backend/entity"
+	handlerIntf "github.com/flash-cards-vocab/backend/internal/api/handler_interfaces"
+	"github.com/flash-cards-vocab/backend/pkg/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handlerFolder struct {
+	folderUsecase folderUC.UseCase
+}
+
+func NewFolderHandler(folderUsecase folderUC.UseCase) handlerIntf.RestFolderHandler {
+	return &handlerFolder{folderUsecase: folderUsecase}
+}
+
+func (h *handlerFolder) GetFolderTree(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	data, err := h.folderUsecase.GetFolderTree(userCtx.UserId)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerFolder) CreateFolder(c *gin.Context) {
+	var request entity.CreateFolderRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	data, err := h.folderUsecase.CreateFolder(userCtx.UserId, request)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerFolder) RenameFolder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	var request entity.RenameFolderRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.folderUsecase.RenameFolder(id, userCtx.UserId, request.Name)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "Folder renamed"})
+}
+
+func (h *handlerFolder) MoveFolder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	var request entity.MoveFolderRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.folderUsecase.MoveFolder(id, userCtx.UserId, request.ParentId)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "Folder moved"})
+}
+
+func (h *handlerFolder) DeleteFolder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.folderUsecase.DeleteFolder(id, userCtx.UserId)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "Folder deleted"})
+}
+
+func (h *handlerFolder) MoveCollectionToFolder(c *gin.Context) {
+	collectionId, err := uuid.Parse(c.Param("collection_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	var request entity.AssignCollectionToFolderRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.folderUsecase.MoveCollectionToFolder(collectionId, userCtx.UserId, request.FolderId)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "Collection moved"})
+}
+
+func (h *handlerFolder) errorResponse(c *gin.Context, err error) {
+	if errors.Is(err, folderUC.ErrNotFound) {
+		c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, folderUC.ErrInvalidName) || errors.Is(err, folderUC.ErrFolderCycle) {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+	}
+}
@@ -13,6 +13,9 @@ type Handler struct {
 	CollectionHandler handlerIntf.RestCollectionHandler
 	UserHandler       handlerIntf.RestUserHandler
 	CardHandler       handlerIntf.RestCardHandler
+	FolderHandler     handlerIntf.RestFolderHandler
+	ModerationHandler handlerIntf.RestModerationHandler
+	LexemeHandler     handlerIntf.RestLexemeHandler
 }
 
 func Get(app *application.Application) *Handler {
@@ -20,11 +23,17 @@ func Get(app *application.Application) *Handler {
 	userHandler := NewUserHandler(uc.UserUsecase)
 	collectionHandler := NewCollectionHandler(uc.CollectionUsecase)
 	cardHandler := NewCardHandler(uc.CardUsecase, os.Getenv("GCS_API_KEY"))
+	folderHandler := NewFolderHandler(uc.FolderUsecase)
+	moderationHandler := NewModerationHandler(uc.ModerationUsecase)
+	lexemeHandler := NewLexemeHandler(uc.LexemeUsecase)
 
 	return &Handler{
 		App:               app,
 		UserHandler:       userHandler,
 		CollectionHandler: collectionHandler,
 		CardHandler:       cardHandler,
+		FolderHandler:     folderHandler,
+		ModerationHandler: moderationHandler,
+		LexemeHandler:     lexemeHandler,
 	}
 }
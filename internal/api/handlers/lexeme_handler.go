@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	lexemeUC "github.com/flash-cards-vocab/backend/app/usecase/lexeme"
+	handlerIntf "github.com/flash-cards-vocab/backend/internal/api/handler_interfaces"
+	"github.com/flash-cards-vocab/backend/pkg/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handlerLexeme struct {
+	lexemeUsecase lexemeUC.UseCase
+}
+
+func NewLexemeHandler(lexemeUsecase lexemeUC.UseCase) handlerIntf.RestLexemeHandler {
+	return &handlerLexeme{lexemeUsecase: lexemeUsecase}
+}
+
+func (h *handlerLexeme) GetLexeme(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	data, err := h.lexemeUsecase.GetLexeme(id)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerLexeme) GetSimilarCards(c *gin.Context) {
+	size, err := strconv.Atoi(c.Query("size"))
+	if err != nil || size < 1 {
+		size = 10
+	}
+
+	data, err := h.lexemeUsecase.GetSimilarCards(c.Query("word"), c.Query("language"), size)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerLexeme) ClusterCards(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	data, err := h.lexemeUsecase.ClusterCards(userCtx.UserId)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerLexeme) errorResponse(c *gin.Context, err error) {
+	if errors.Is(err, lexemeUC.ErrNotFound) {
+		c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, lexemeUC.ErrInvalidWord) || errors.Is(err, lexemeUC.ErrInvalidLanguage) {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, lexemeUC.ErrUnauthorized) {
+		c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	moderationUC "github.com/flash-cards-vocab/backend/app/usecase/moderation"
+	"github.com/flash-cards-vocab/backend/entity"
+	handlerIntf "github.com/flash-cards-vocab/backend/internal/api/handler_interfaces"
+	"github.com/flash-cards-vocab/backend/pkg/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type handlerModeration struct {
+	moderationUsecase moderationUC.UseCase
+}
+
+func NewModerationHandler(moderationUsecase moderationUC.UseCase) handlerIntf.RestModerationHandler {
+	return &handlerModeration{moderationUsecase: moderationUsecase}
+}
+
+func (h *handlerModeration) ReportCollection(c *gin.Context) {
+	h.reportContent(c, entity.ReportTargetType_Collection)
+}
+
+func (h *handlerModeration) ReportCard(c *gin.Context) {
+	h.reportContent(c, entity.ReportTargetType_Card)
+}
+
+func (h *handlerModeration) ReportMnemonic(c *gin.Context) {
+	h.reportContent(c, entity.ReportTargetType_Mnemonic)
+}
+
+func (h *handlerModeration) reportContent(c *gin.Context, targetType entity.ReportTargetType) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	var request entity.ReportRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	data, err := h.moderationUsecase.ReportContent(targetType, id, userCtx.UserId, request)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerModeration) GetReports(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+	status := entity.ReportStatus(c.DefaultQuery("status", string(entity.ReportStatus_Open)))
+	page, err := strconv.Atoi(c.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	size, err := strconv.Atoi(c.Query("size"))
+	if err != nil || size < 1 {
+		size = 20
+	}
+
+	data, err := h.moderationUsecase.GetReports(userCtx.UserId, status, page, size)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerModeration) ModerateReport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	var request entity.ModerationActionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	err = h.moderationUsecase.ModerateReport(id, userCtx.UserId, request)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: "success"})
+}
+
+func (h *handlerModeration) GetMyWarnings(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	data, err := h.moderationUsecase.GetUserWarnings(userCtx.UserId)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerModeration) errorResponse(c *gin.Context, err error) {
+	if errors.Is(err, moderationUC.ErrNotFound) {
+		c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, moderationUC.ErrInvalidReason) || errors.Is(err, moderationUC.ErrInvalidAction) {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, moderationUC.ErrUnauthorized) || errors.Is(err, moderationUC.ErrForbiddenSelfRequest) {
+		c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else if errors.Is(err, moderationUC.ErrAlreadyReported) || errors.Is(err, moderationUC.ErrAlreadyResolved) {
+		c.JSON(http.StatusConflict, handlerIntf.ErrorResponse{Message: err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+	}
+}
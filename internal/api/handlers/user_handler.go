@@ -9,6 +9,7 @@ import (
 	handlerIntf "github.com/flash-cards-vocab/backend/internal/api/handler_interfaces"
 	"github.com/flash-cards-vocab/backend/pkg/helpers"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type handlerUser struct {
@@ -147,3 +148,44 @@ func (h *handlerUser) Login(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
 }
+
+func (h *handlerUser) RequestDataExport(c *gin.Context) {
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	data, err := h.userUsecase.RequestDataExport(userCtx.UserId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, handlerIntf.SuccessResponse{Result: data})
+}
+
+func (h *handlerUser) GetDataExport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlerIntf.ErrorResponse{Message: err.Error()})
+		return
+	}
+	userCtx, err := helpers.GetAuthContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: "User id not found"})
+		return
+	}
+
+	data, err := h.userUsecase.GetDataExport(id, userCtx.UserId)
+	if err != nil {
+		if errors.Is(err, userUC.ErrNotFound) {
+			c.JSON(http.StatusNotFound, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else if errors.Is(err, userUC.ErrUnauthorized) {
+			c.JSON(http.StatusForbidden, handlerIntf.ErrorResponse{Message: err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, handlerIntf.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, handlerIntf.SuccessResponse{Result: data})
+}
@@ -19,9 +19,11 @@ func NewRouter(app *application.Application) (*gin.Engine, error) {
 	user := v1.Group("/user")
 	// User GET requests
 	user.GET("/profile", middleware.AuthorizeJWT, h.UserHandler.GetProfile)
+	user.GET("/export/:id", middleware.AuthorizeJWT, h.UserHandler.GetDataExport)
 	// User POST requests
 	user.POST("/login", h.UserHandler.Login)
 	user.POST("/register", h.UserHandler.Register)
+	user.POST("/export", middleware.AuthorizeJWT, h.UserHandler.RequestDataExport)
 	user.GET("/register/username-exists/:username", h.UserHandler.UsernameExists)
 
 	// Collection routes
@@ -34,9 +36,16 @@ func NewRouter(app *application.Application) (*gin.Engine, error) {
 	collection.GET("/metrics/:id", middleware.AuthorizeJWT, h.CollectionHandler.GetCollectionMetricsById)
 	collection.GET("/full/:id", middleware.AuthorizeJWT, h.CollectionHandler.GetCollectionWithCards)
 	collection.GET("/search/:query", middleware.AuthorizeJWT, h.CollectionHandler.SearchCollectionByName)
+	collection.GET("/trending", middleware.AuthorizeJWT, h.CollectionHandler.GetTrendingCollections)
+	collection.GET("/reviews/:id", middleware.AuthorizeJWT, h.CollectionHandler.GetCollectionReviews)
+	collection.GET("/export/:id", middleware.AuthorizeJWT, h.CollectionHandler.ExportCollection)
 	// Collection POST requests
 	collection.POST("/create", middleware.AuthorizeJWT, h.CollectionHandler.CreateCollection)
 	collection.POST("/upload-collection-with-file", middleware.AuthorizeJWT, h.CollectionHandler.UploadCollectionWithFile)
+	collection.POST("/import/anki", middleware.AuthorizeJWT, h.CollectionHandler.ImportAnkiCollection)
+	collection.POST("/import/table", middleware.AuthorizeJWT, h.CollectionHandler.ImportTable)
+	collection.POST("/import/text", middleware.AuthorizeJWT, h.CollectionHandler.ImportText)
+	collection.POST("/report/:id", middleware.AuthorizeJWT, h.ModerationHandler.ReportCollection)
 	// Collection PUT requests
 	collection.PUT("/update-user-progress/:id", middleware.AuthorizeJWT, h.CollectionHandler.UpdateCollectionUserProgress)
 	collection.PUT("/star/:id", middleware.AuthorizeJWT, h.CollectionHandler.StarCollectionById)
@@ -44,17 +53,73 @@ func NewRouter(app *application.Application) (*gin.Engine, error) {
 	collection.PUT("/dislike/:id", middleware.AuthorizeJWT, h.CollectionHandler.DislikeCollectionById)
 	collection.PUT("/view/:id", middleware.AuthorizeJWT, h.CollectionHandler.ViewCollectionById)
 	collection.PUT("/update", middleware.AuthorizeJWT, h.CollectionHandler.UpdateCollection)
+	collection.PUT("/review/:id", middleware.AuthorizeJWT, h.CollectionHandler.ReviewCollection)
+	collection.PUT("/review/reply/:review_id", middleware.AuthorizeJWT, h.CollectionHandler.ReplyToCollectionReview)
+	// Collection DELETE requests
+	collection.DELETE("/review/:id", middleware.AuthorizeJWT, h.CollectionHandler.DeleteCollectionReview)
+
+	// Import job routes
+	importJob := v1.Group("/import")
+	// Import job GET requests
+	importJob.GET("/:id", middleware.AuthorizeJWT, h.CollectionHandler.GetImportJob)
+	// Import job PUT requests
+	importJob.PUT("/cancel/:id", middleware.AuthorizeJWT, h.CollectionHandler.CancelImportJob)
 
 	// Card routes
 	card := v1.Group("/card")
 	// Card GET requests
 	card.GET("/search-by-word", middleware.AuthorizeJWT, h.CardHandler.SearchByWord)
+	card.GET("/revisions/:id", middleware.AuthorizeJWT, h.CardHandler.GetCardRevisions)
+	card.GET("/related/:id", middleware.AuthorizeJWT, h.CardHandler.GetRelatedCards)
+	card.GET("/mnemonics/:id", middleware.AuthorizeJWT, h.CardHandler.GetCardMnemonics)
+	card.GET("/similar", middleware.AuthorizeJWT, h.LexemeHandler.GetSimilarCards)
 	// Card POST requests
 	card.POST("/upload-card-image", middleware.AuthorizeJWT, h.CardHandler.UploadCardImage)
+	card.POST("/upload-card-audio", middleware.AuthorizeJWT, h.CardHandler.UploadCardAudio)
 	card.POST("/add-card-to-collection/:collection_id/:card_id", middleware.AuthorizeJWT, h.CardHandler.AddExistingCardToCollection)
+	card.POST("/report/:id", middleware.AuthorizeJWT, h.ModerationHandler.ReportCard)
+	card.POST("/mnemonic/publish/:id", middleware.AuthorizeJWT, h.CardHandler.PublishCardMnemonic)
+	card.POST("/mnemonic/report/:id", middleware.AuthorizeJWT, h.ModerationHandler.ReportMnemonic)
 	// Card PUT requests
 	card.PUT("/know/:card_id/:collection_id", middleware.AuthorizeJWT, h.CardHandler.KnowCard)
 	card.PUT("/dont-know/:card_id/:collection_id", middleware.AuthorizeJWT, h.CardHandler.DontKnowCard)
+	card.PUT("/like/:id", middleware.AuthorizeJWT, h.CardHandler.LikeCardById)
+	card.PUT("/dislike/:id", middleware.AuthorizeJWT, h.CardHandler.DislikeCardById)
+	card.PUT("/generate-audio/:collection_id", middleware.AuthorizeJWT, h.CardHandler.GenerateMissingAudio)
+	card.PUT("/note/:id", middleware.AuthorizeJWT, h.CardHandler.SaveCardNote)
+	card.PUT("/mnemonic/upvote/:id", middleware.AuthorizeJWT, h.CardHandler.UpvoteCardMnemonic)
+	card.PUT("/mnemonic/downvote/:id", middleware.AuthorizeJWT, h.CardHandler.DownvoteCardMnemonic)
+	// Card DELETE requests
+	card.DELETE("/note/:id", middleware.AuthorizeJWT, h.CardHandler.DeleteCardNote)
+	card.DELETE("/mnemonic/:id", middleware.AuthorizeJWT, h.CardHandler.DeleteCardMnemonic)
+
+	// Folder routes
+	folder := v1.Group("/folder")
+	// Folder GET requests
+	folder.GET("/tree", middleware.AuthorizeJWT, h.FolderHandler.GetFolderTree)
+	// Folder POST requests
+	folder.POST("/create", middleware.AuthorizeJWT, h.FolderHandler.CreateFolder)
+	// Folder PUT requests
+	folder.PUT("/rename/:id", middleware.AuthorizeJWT, h.FolderHandler.RenameFolder)
+	folder.PUT("/move/:id", middleware.AuthorizeJWT, h.FolderHandler.MoveFolder)
+	folder.PUT("/move-collection/:collection_id", middleware.AuthorizeJWT, h.FolderHandler.MoveCollectionToFolder)
+	// Folder DELETE requests
+	folder.DELETE("/:id", middleware.AuthorizeJWT, h.FolderHandler.DeleteFolder)
+
+	// Moderation routes
+	moderation := v1.Group("/moderation")
+	// Moderation GET requests
+	moderation.GET("/reports", middleware.AuthorizeJWT, h.ModerationHandler.GetReports)
+	moderation.GET("/my-warnings", middleware.AuthorizeJWT, h.ModerationHandler.GetMyWarnings)
+	// Moderation PUT requests
+	moderation.PUT("/action/:id", middleware.AuthorizeJWT, h.ModerationHandler.ModerateReport)
+
+	// Lexeme routes
+	lexeme := v1.Group("/lexeme")
+	// Lexeme GET requests
+	lexeme.GET("/:id", middleware.AuthorizeJWT, h.LexemeHandler.GetLexeme)
+	// Lexeme POST requests
+	lexeme.POST("/cluster", middleware.AuthorizeJWT, h.LexemeHandler.ClusterCards)
 
 	// Open routes
 	unregistered := v1.Group("/unregistered")
@@ -63,6 +128,7 @@ func NewRouter(app *application.Application) (*gin.Engine, error) {
 	collectionUnregistered.GET("/recommended", h.CollectionHandler.UnregisteredGetRecommendedCollectionsPreview)
 	collectionUnregistered.GET("/full/:id", h.CollectionHandler.UnregisteredGetCollectionWithCards)
 	collectionUnregistered.GET("/search/:query", h.CollectionHandler.UnregisteredSearchCollectionByName)
+	collectionUnregistered.GET("/reviews/:id", h.CollectionHandler.UnregisteredGetCollectionReviews)
 
 	// Open card routes
 	// cardUnregistered := unregistered.Group("/card")
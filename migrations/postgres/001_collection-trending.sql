@@ -0,0 +1,30 @@
+DROP TABLE IF EXISTS collection_event;
+DROP TABLE IF EXISTS collection_trending;
+DROP TYPE IF EXISTS collection_event_type_enum;
+
+CREATE TYPE collection_event_type_enum AS enum('like', 'view');
+
+CREATE TABLE collection_event (
+    id uuid NOT NULL,
+    collection_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    type collection_event_type_enum NOT NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX collection_event_created_at_idx ON collection_event (created_at);
+
+CREATE TABLE collection_trending (
+    id uuid NOT NULL,
+    collection_id uuid NOT NULL,
+    time_window VARCHAR (10) NOT NULL,
+    score DOUBLE PRECISION NOT NULL default 0,
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX collection_trending_window_score_idx ON collection_trending (time_window, score DESC);
@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS folder;
+DROP TABLE IF EXISTS folder_collections;
+
+CREATE TABLE folder (
+    id uuid NOT NULL,
+    name VARCHAR (150) NOT NULL,
+    parent_id uuid NULL,
+    user_id uuid NOT NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX folder_user_id_idx ON folder (user_id);
+
+CREATE TABLE folder_collections (
+    id uuid NOT NULL,
+    folder_id uuid NOT NULL,
+    collection_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX folder_collections_user_collection_idx ON folder_collections (user_id, collection_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,19 @@
+DROP TABLE IF EXISTS collection_review;
+
+ALTER TABLE collection_metrics ADD COLUMN ratings INT NOT NULL default 0;
+ALTER TABLE collection_metrics ADD COLUMN rating_sum INT NOT NULL default 0;
+
+CREATE TABLE collection_review (
+    id uuid NOT NULL,
+    collection_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text TEXT NOT NULL DEFAULT '',
+    reply TEXT NOT NULL DEFAULT '',
+    replied_at TIMESTAMPTZ NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX collection_review_collection_user_idx ON collection_review (collection_id, user_id) WHERE deleted_at IS NULL;
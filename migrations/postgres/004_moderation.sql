@@ -0,0 +1,42 @@
+DROP TABLE IF EXISTS report;
+DROP TABLE IF EXISTS user_warning;
+
+ALTER TABLE users ADD COLUMN role VARCHAR (20) NOT NULL DEFAULT 'user';
+ALTER TABLE collection ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE card ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE report (
+    id uuid NOT NULL,
+    reporter_id uuid NOT NULL,
+    target_type VARCHAR (20) NOT NULL,
+    target_id uuid NOT NULL,
+    author_id uuid NOT NULL,
+    reason VARCHAR (30) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    status VARCHAR (20) NOT NULL DEFAULT 'open',
+    action VARCHAR (20) NOT NULL DEFAULT '',
+    moderator_id uuid NULL,
+    note TEXT NOT NULL DEFAULT '',
+    resolved_at TIMESTAMPTZ NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX report_status_idx ON report (status);
+CREATE INDEX report_target_idx ON report (target_type, target_id);
+
+CREATE TABLE user_warning (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    report_id uuid NOT NULL,
+    target_type VARCHAR (20) NOT NULL,
+    target_id uuid NOT NULL,
+    reason VARCHAR (30) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX user_warning_user_id_idx ON user_warning (user_id);
@@ -0,0 +1,7 @@
+ALTER TABLE collection ADD COLUMN source_language VARCHAR (35) NOT NULL DEFAULT '';
+ALTER TABLE collection ADD COLUMN target_language VARCHAR (35) NOT NULL DEFAULT '';
+ALTER TABLE card ADD COLUMN source_language VARCHAR (35) NOT NULL DEFAULT '';
+ALTER TABLE card ADD COLUMN target_language VARCHAR (35) NOT NULL DEFAULT '';
+
+CREATE INDEX collection_language_idx ON collection (source_language, target_language);
+CREATE INDEX card_language_idx ON card (source_language, target_language);
@@ -0,0 +1,5 @@
+ALTER TABLE collection ADD COLUMN level VARCHAR (30) NOT NULL DEFAULT '';
+ALTER TABLE card ADD COLUMN level VARCHAR (30) NOT NULL DEFAULT '';
+
+CREATE INDEX collection_level_idx ON collection (level);
+CREATE INDEX card_level_idx ON card (level);
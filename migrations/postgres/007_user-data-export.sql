@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS user_data_export;
+
+CREATE TABLE user_data_export (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    status VARCHAR (20) NOT NULL DEFAULT 'pending',
+    object_name TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMPTZ NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX user_data_export_user_id_idx ON user_data_export (user_id);
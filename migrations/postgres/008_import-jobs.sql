@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS import_job;
+
+CREATE TABLE import_job (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    status VARCHAR (20) NOT NULL DEFAULT 'pending',
+    filename VARCHAR (255) NOT NULL DEFAULT '',
+    file BYTEA NULL,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    errors TEXT[] NOT NULL DEFAULT '{}',
+    result TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMPTZ NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX import_job_user_id_idx ON import_job (user_id);
+CREATE INDEX import_job_status_idx ON import_job (status);
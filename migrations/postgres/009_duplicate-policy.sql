@@ -0,0 +1,3 @@
+ALTER TABLE import_job ADD COLUMN duplicate_policy VARCHAR (20) NOT NULL DEFAULT 'keep';
+
+CREATE INDEX card_author_word_idx ON card (author_id, LOWER(TRIM(word)));
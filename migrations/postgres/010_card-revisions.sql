@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS card_revision;
+
+CREATE TABLE card_revision (
+    id uuid NOT NULL,
+    card_id uuid NOT NULL,
+    revision INTEGER NOT NULL,
+    word VARCHAR (150) NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    definition TEXT NOT NULL DEFAULT '',
+    sentence TEXT NOT NULL DEFAULT '',
+    antonyms VARCHAR (150) NOT NULL DEFAULT '',
+    synonyms VARCHAR (150) NOT NULL DEFAULT '',
+    source_language VARCHAR (35) NOT NULL DEFAULT '',
+    target_language VARCHAR (35) NOT NULL DEFAULT '',
+    level VARCHAR (30) NOT NULL DEFAULT '',
+    editor_id uuid NOT NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX card_revision_card_revision_idx ON card_revision (card_id, revision);
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS card_user_metrics;
+
+CREATE TABLE card_user_metrics (
+    id uuid NOT NULL,
+    card_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    liked BOOLEAN NOT NULL DEFAULT FALSE,
+    disliked BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX card_user_metrics_card_user_idx ON card_user_metrics (card_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX card_metrics_card_id_idx ON card_metrics (card_id);
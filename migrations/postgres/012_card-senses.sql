@@ -0,0 +1,9 @@
+ALTER TABLE card ADD COLUMN senses TEXT NOT NULL DEFAULT '';
+ALTER TABLE card ADD COLUMN pronunciation VARCHAR (150) NOT NULL DEFAULT '';
+ALTER TABLE card ADD COLUMN gender VARCHAR (30) NOT NULL DEFAULT '';
+ALTER TABLE card ADD COLUMN plural VARCHAR (150) NOT NULL DEFAULT '';
+
+ALTER TABLE card_revision ADD COLUMN senses TEXT NOT NULL DEFAULT '';
+ALTER TABLE card_revision ADD COLUMN pronunciation VARCHAR (150) NOT NULL DEFAULT '';
+ALTER TABLE card_revision ADD COLUMN gender VARCHAR (30) NOT NULL DEFAULT '';
+ALTER TABLE card_revision ADD COLUMN plural VARCHAR (150) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE card ADD COLUMN audio_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE card_revision ADD COLUMN audio_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+ALTER TABLE card ALTER COLUMN antonyms TYPE TEXT;
+ALTER TABLE card ALTER COLUMN synonyms TYPE TEXT;
+ALTER TABLE card ADD COLUMN antonym_list TEXT NOT NULL DEFAULT '';
+ALTER TABLE card ADD COLUMN synonym_list TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE card_revision ALTER COLUMN antonyms TYPE TEXT;
+ALTER TABLE card_revision ALTER COLUMN synonyms TYPE TEXT;
+ALTER TABLE card_revision ADD COLUMN antonym_list TEXT NOT NULL DEFAULT '';
+ALTER TABLE card_revision ADD COLUMN synonym_list TEXT NOT NULL DEFAULT '';
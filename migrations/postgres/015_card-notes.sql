@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS card_note;
+
+CREATE TABLE card_note (
+    id uuid NOT NULL,
+    card_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    mnemonic TEXT NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX card_note_card_user_idx ON card_note (card_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX card_note_user_id_idx ON card_note (user_id);
@@ -0,0 +1,31 @@
+DROP TABLE IF EXISTS card_mnemonic;
+DROP TABLE IF EXISTS card_mnemonic_vote;
+
+CREATE TABLE card_mnemonic (
+    id uuid NOT NULL,
+    card_id uuid NOT NULL,
+    author_id uuid NOT NULL,
+    text TEXT NOT NULL,
+    upvotes INTEGER NOT NULL DEFAULT 0,
+    downvotes INTEGER NOT NULL DEFAULT 0,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX card_mnemonic_card_id_idx ON card_mnemonic (card_id);
+CREATE INDEX card_mnemonic_author_id_idx ON card_mnemonic (author_id, created_at);
+CREATE UNIQUE INDEX card_mnemonic_card_author_idx ON card_mnemonic (card_id, author_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE card_mnemonic_vote (
+    id uuid NOT NULL,
+    mnemonic_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    vote SMALLINT NOT NULL DEFAULT 0,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX card_mnemonic_vote_mnemonic_user_idx ON card_mnemonic_vote (mnemonic_id, user_id) WHERE deleted_at IS NULL;
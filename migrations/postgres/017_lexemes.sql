@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS lexeme;
+
+CREATE TABLE lexeme (
+    id uuid NOT NULL,
+    word TEXT NOT NULL,
+    language VARCHAR (35) NOT NULL DEFAULT '',
+    card_count INTEGER NOT NULL DEFAULT 0,
+    canonical_card_id uuid NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX lexeme_word_language_idx ON lexeme (word, language) WHERE deleted_at IS NULL;
+
+ALTER TABLE card ADD COLUMN lexeme_id uuid NULL;
+CREATE INDEX card_lexeme_id_idx ON card (lexeme_id);
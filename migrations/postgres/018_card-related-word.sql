@@ -0,0 +1,24 @@
+DROP TABLE IF EXISTS card_related_word;
+
+CREATE TABLE card_related_word (
+    id uuid NOT NULL,
+    card_id uuid NOT NULL,
+    related_card_id uuid NULL,
+    word TEXT NOT NULL,
+    relation VARCHAR (20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX card_related_word_card_id_idx ON card_related_word (card_id);
+CREATE INDEX card_related_word_related_card_id_idx ON card_related_word (related_card_id);
+
+INSERT INTO card_related_word (id, card_id, related_card_id, word, relation)
+SELECT gen_random_uuid(), c.id, (w ->> 'cardId')::uuid, w ->> 'word', 'synonym'
+FROM card c, json_array_elements(c.synonym_list::json) w
+WHERE c.synonym_list <> '';
+
+INSERT INTO card_related_word (id, card_id, related_card_id, word, relation)
+SELECT gen_random_uuid(), c.id, (w ->> 'cardId')::uuid, w ->> 'word', 'antonym'
+FROM card c, json_array_elements(c.antonym_list::json) w
+WHERE c.antonym_list <> '';
@@ -0,0 +1,51 @@
+package anki
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var imageSourceRegexp = regexp.MustCompile(`(?i)<img[^>]*\ssrc\s*=\s*["']?([^"'\s>]+)`)
+var soundRegexp = regexp.MustCompile(`\[sound:([^\]]*)\]`)
+var lineBreakRegexp = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>`)
+var tagRegexp = regexp.MustCompile(`<[^>]*>`)
+var spacesRegexp = regexp.MustCompile(`[ \t]+`)
+
+// ImageSources returns the media filenames of the images embedded in the field
+func ImageSources(value string) []string {
+	sources := []string{}
+	for _, match := range imageSourceRegexp.FindAllStringSubmatch(value, -1) {
+		sources = append(sources, html.UnescapeString(match[1]))
+	}
+	return sources
+}
+
+// SoundSources returns the media filenames of the sounds played by the field
+func SoundSources(value string) []string {
+	sources := []string{}
+	for _, match := range soundRegexp.FindAllStringSubmatch(value, -1) {
+		if source := strings.TrimSpace(match[1]); source != "" {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// PlainText converts a field value to plain text, dropping HTML tags, images and sounds
+func PlainText(value string) string {
+	value = soundRegexp.ReplaceAllString(value, "")
+	value = lineBreakRegexp.ReplaceAllString(value, "\n")
+	value = tagRegexp.ReplaceAllString(value, "")
+	value = html.UnescapeString(value)
+	value = strings.ReplaceAll(value, " ", " ")
+
+	lines := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(spacesRegexp.ReplaceAllString(line, " "))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,269 @@
+package anki
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var ErrInvalidPackage = errors.New("file is not a valid Anki package")
+var ErrUnsupportedPackage = errors.New("Anki package format is not supported, export it with \"Support older Anki versions\" enabled")
+var ErrMediaNotFound = errors.New("media file not found in the Anki package")
+var ErrMediaTooLarge = errors.New("media file in the Anki package is too large")
+
+const fieldSeparator = "\x1f"
+
+// The entries are inflated at most to these sizes so that a small archive cannot fill the disk or the memory
+const (
+	// MaxPackageSize is the largest .apkg file accepted for import
+	MaxPackageSize = 100 << 20
+	// MaxMediaSize is the largest inflated media file
+	MaxMediaSize      = 20 << 20
+	maxCollectionSize = 200 << 20
+	maxMediaIndexSize = 5 << 20
+)
+
+type Note struct {
+	Id        int64
+	ModelName string
+	// Fields holds the note field values by field name
+	Fields     map[string]string
+	FieldNames []string
+	Tags       []string
+}
+
+// Package is an opened .apkg file, media files are read lazily from the archive
+type Package struct {
+	Notes []*Note
+	// media maps a media filename referenced by the notes to its entry in the archive
+	media   map[string]*zip.File
+	archive *zip.Reader
+}
+
+type model struct {
+	Name   string
+	Fields []string
+}
+
+// Read parses an .apkg archive: a zip with the SQLite collection and the numbered media files
+func Read(r io.ReaderAt, size int64) (*Package, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, ErrInvalidPackage
+	}
+	entries := map[string]*zip.File{}
+	for _, file := range archive.File {
+		entries[file.Name] = file
+	}
+
+	collectionFile, ok := entries["collection.anki21"]
+	if !ok {
+		if _, ok := entries["collection.anki21b"]; ok {
+			return nil, ErrUnsupportedPackage
+		}
+		collectionFile, ok = entries["collection.anki2"]
+		if !ok {
+			return nil, ErrInvalidPackage
+		}
+	}
+
+	notes, err := readNotes(collectionFile)
+	if err != nil {
+		return nil, err
+	}
+	media, err := readMedia(entries)
+	if err != nil {
+		return nil, err
+	}
+	return &Package{
+		Notes:   notes,
+		media:   media,
+		archive: archive,
+	}, nil
+}
+
+// OpenMedia opens the media file with the name used in the note fields
+func (p *Package) OpenMedia(filename string) (io.ReadCloser, error) {
+	file, ok := p.media[filename]
+	if !ok {
+		return nil, ErrMediaNotFound
+	}
+	if file.UncompressedSize64 > MaxMediaSize {
+		return nil, ErrMediaTooLarge
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	return &limitedReadCloser{Reader: io.LimitReader(reader, MaxMediaSize), Closer: reader}, nil
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func readMedia(entries map[string]*zip.File) (map[string]*zip.File, error) {
+	media := map[string]*zip.File{}
+	mediaFile, ok := entries["media"]
+	if !ok {
+		return media, nil
+	}
+	reader, err := mediaFile.Open()
+	if err != nil {
+		return nil, ErrInvalidPackage
+	}
+	defer reader.Close()
+
+	// The media file maps the archive entry names to the original filenames, e.g. {"0": "cat.jpg"}
+	mapping := map[string]string{}
+	if err := json.NewDecoder(io.LimitReader(reader, maxMediaIndexSize)).Decode(&mapping); err != nil {
+		return nil, ErrInvalidPackage
+	}
+	for entryName, filename := range mapping {
+		if entry, ok := entries[entryName]; ok {
+			media[filename] = entry
+		}
+	}
+	return media, nil
+}
+
+func readNotes(collectionFile *zip.File) ([]*Note, error) {
+	if collectionFile.UncompressedSize64 > maxCollectionSize {
+		return nil, fmt.Errorf("%w: collection is larger than %d bytes", ErrInvalidPackage, maxCollectionSize)
+	}
+	// SQLite needs a file on disk
+	tmp, err := os.CreateTemp("", "anki-*.sqlite")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	reader, err := collectionFile.Open()
+	if err != nil {
+		tmp.Close()
+		return nil, ErrInvalidPackage
+	}
+	// The size in the header can be forged, one byte over the limit tells a larger collection
+	written, err := io.Copy(tmp, io.LimitReader(reader, maxCollectionSize+1))
+	reader.Close()
+	tmp.Close()
+	if err != nil {
+		return nil, ErrInvalidPackage
+	}
+	if written > maxCollectionSize {
+		return nil, fmt.Errorf("%w: collection is larger than %d bytes", ErrInvalidPackage, maxCollectionSize)
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+tmp.Name()+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	models, err := readModels(db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT id, mid, flds, tags FROM notes ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+	defer rows.Close()
+
+	notes := []*Note{}
+	for rows.Next() {
+		var id, modelId int64
+		var fields, tags string
+		if err := rows.Scan(&id, &modelId, &fields, &tags); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+		}
+		note := &Note{
+			Id:     id,
+			Fields: map[string]string{},
+			Tags:   strings.Fields(tags),
+		}
+		values := strings.Split(fields, fieldSeparator)
+		m, ok := models[modelId]
+		if ok {
+			note.ModelName = m.Name
+		}
+		for i, value := range values {
+			name := fmt.Sprintf("Field %d", i+1)
+			if ok && i < len(m.Fields) {
+				name = m.Fields[i]
+			}
+			note.Fields[name] = value
+			note.FieldNames = append(note.FieldNames, name)
+		}
+		notes = append(notes, note)
+	}
+	return notes, rows.Err()
+}
+
+// readModels returns the note types by id, older collections keep them as JSON in the col table
+// and newer ones in the notetypes and fields tables
+func readModels(db *sql.DB) (map[int64]*model, error) {
+	models := map[int64]*model{}
+
+	var modelsJson string
+	err := db.QueryRow("SELECT models FROM col").Scan(&modelsJson)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+	if modelsJson != "" && modelsJson != "{}" {
+		raw := map[string]struct {
+			Id     int64  `json:"id"`
+			Name   string `json:"name"`
+			Fields []struct {
+				Name string `json:"name"`
+				Ord  int    `json:"ord"`
+			} `json:"flds"`
+		}{}
+		if err := json.Unmarshal([]byte(modelsJson), &raw); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+		}
+		for _, rawModel := range raw {
+			sort.Slice(rawModel.Fields, func(i, j int) bool { return rawModel.Fields[i].Ord < rawModel.Fields[j].Ord })
+			m := &model{Name: rawModel.Name}
+			for _, field := range rawModel.Fields {
+				m.Fields = append(m.Fields, field.Name)
+			}
+			models[rawModel.Id] = m
+		}
+		return models, nil
+	}
+
+	rows, err := db.Query(`
+		SELECT nt.id, nt.name, f.name
+		FROM notetypes AS nt
+		INNER JOIN fields AS f ON f.ntid = nt.id
+		ORDER BY nt.id, f.ord`)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var name, fieldName string
+		if err := rows.Scan(&id, &name, &fieldName); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+		}
+		m, ok := models[id]
+		if !ok {
+			m = &model{Name: name}
+			models[id] = m
+		}
+		m.Fields = append(m.Fields, fieldName)
+	}
+	return models, rows.Err()
+}
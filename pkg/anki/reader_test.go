@@ -0,0 +1,172 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func testDeck() *Deck {
+	return &Deck{
+		Name: "Animals",
+		NoteType: NoteType{
+			Name:           "Vocabulary",
+			Fields:         []string{"Word", "Definition"},
+			QuestionFormat: "{{Word}}",
+			AnswerFormat:   "{{Definition}}",
+		},
+		Notes: []*ExportNote{
+			{Guid: "a", Fields: []string{"cat", "a small pet"}, Tags: []string{"pets"}},
+			{Guid: "b", Fields: []string{"dog", `<img src="dog.jpg">`}},
+		},
+		Media: map[string][]byte{"dog.jpg": []byte("jpeg")},
+	}
+}
+
+func writePackage(t *testing.T, deck *Deck) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	if err := Write(buf, deck); err != nil {
+		t.Fatalf("write package: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// rewritePackage copies the package with its entries renamed, an empty new name drops the entry,
+// and the extra entries added
+func rewritePackage(t *testing.T, data []byte, names map[string]string, extra map[string]string) []byte {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("open package: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	writer := zip.NewWriter(buf)
+	for _, file := range archive.File {
+		name := file.Name
+		if newName, ok := names[name]; ok {
+			name = newName
+		}
+		if name == "" {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := io.Copy(entry, reader); err != nil {
+			t.Fatalf("copy %s: %v", name, err)
+		}
+		reader.Close()
+	}
+	for name, content := range extra {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		entry.Write([]byte(content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close package: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	valid := writePackage(t, testDeck())
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "anki2 collection", data: valid},
+		{name: "anki21 collection", data: rewritePackage(t, valid, map[string]string{"collection.anki2": "collection.anki21"}, nil)},
+		{name: "without media", data: rewritePackage(t, valid, map[string]string{"media": ""}, nil)},
+		{name: "anki21b collection", data: rewritePackage(t, valid, map[string]string{"collection.anki2": "collection.anki21b"}, nil), wantErr: ErrUnsupportedPackage},
+		{name: "without collection", data: rewritePackage(t, valid, map[string]string{"collection.anki2": ""}, nil), wantErr: ErrInvalidPackage},
+		{
+			name:    "collection is not SQLite",
+			data:    rewritePackage(t, valid, map[string]string{"collection.anki2": ""}, map[string]string{"collection.anki2": "not a database"}),
+			wantErr: ErrInvalidPackage,
+		},
+		{name: "media is not JSON", data: rewritePackage(t, valid, map[string]string{"media": ""}, map[string]string{"media": "["}), wantErr: ErrInvalidPackage},
+		{name: "truncated archive", data: valid[:len(valid)/2], wantErr: ErrInvalidPackage},
+		{name: "not a zip", data: []byte("SQLite format 3"), wantErr: ErrInvalidPackage},
+		{name: "empty", data: []byte{}, wantErr: ErrInvalidPackage},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkg, err := Read(bytes.NewReader(test.data), int64(len(test.data)))
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("got error %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pkg.Notes) != 2 {
+				t.Fatalf("got %d notes, want 2", len(pkg.Notes))
+			}
+			note := pkg.Notes[0]
+			if note.ModelName != "Vocabulary" || note.Fields["Word"] != "cat" || note.Fields["Definition"] != "a small pet" {
+				t.Errorf("unexpected note %+v", note)
+			}
+			if len(note.FieldNames) != 2 || note.FieldNames[0] != "Word" || note.FieldNames[1] != "Definition" {
+				t.Errorf("unexpected field names %v", note.FieldNames)
+			}
+			if len(note.Tags) != 1 || note.Tags[0] != "pets" {
+				t.Errorf("unexpected tags %v", note.Tags)
+			}
+		})
+	}
+}
+
+func TestPackageOpenMedia(t *testing.T) {
+	data := writePackage(t, testDeck())
+	pkg, err := Read(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("read package: %v", err)
+	}
+	tests := []struct {
+		name     string
+		filename string
+		// size overrides the inflated size in the archive header
+		size    uint64
+		want    string
+		wantErr error
+	}{
+		{name: "referenced file", filename: "dog.jpg", want: "jpeg"},
+		{name: "missing file", filename: "cat.jpg", wantErr: ErrMediaNotFound},
+		{name: "file larger than the limit", filename: "dog.jpg", size: MaxMediaSize + 1, wantErr: ErrMediaTooLarge},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.size > 0 {
+				pkg.media[test.filename].UncompressedSize64 = test.size
+			}
+			reader, err := pkg.OpenMedia(test.filename)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("got error %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer reader.Close()
+			content, _ := io.ReadAll(reader)
+			if string(content) != test.want {
+				t.Errorf("got %q, want %q", content, test.want)
+			}
+		})
+	}
+}
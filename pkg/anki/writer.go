@@ -0,0 +1,299 @@
+package anki
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ease factor of new Anki cards in permille
+const defaultEaseFactor = 2500
+
+const schema = `
+CREATE TABLE col (
+	id integer primary key, crt integer not null, mod integer not null, scm integer not null,
+	ver integer not null, dty integer not null, usn integer not null, ls integer not null,
+	conf text not null, models text not null, decks text not null, dconf text not null, tags text not null
+);
+CREATE TABLE notes (
+	id integer primary key, guid text not null, mid integer not null, mod integer not null,
+	usn integer not null, tags text not null, flds text not null, sfld integer not null,
+	csum integer not null, flags integer not null, data text not null
+);
+CREATE TABLE cards (
+	id integer primary key, nid integer not null, did integer not null, ord integer not null,
+	mod integer not null, usn integer not null, type integer not null, queue integer not null,
+	due integer not null, ivl integer not null, factor integer not null, reps integer not null,
+	lapses integer not null, left integer not null, odue integer not null, odid integer not null,
+	flags integer not null, data text not null
+);
+CREATE TABLE revlog (
+	id integer primary key, cid integer not null, usn integer not null, ease integer not null,
+	ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null,
+	type integer not null
+);
+CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+// NoteType describes the fields of the exported notes and how a card shows them
+type NoteType struct {
+	Name           string
+	Fields         []string
+	QuestionFormat string
+	AnswerFormat   string
+	Css            string
+}
+
+// Schedule places a card in the Anki review queue, notes without a schedule are exported as new cards
+type Schedule struct {
+	// Interval is the number of days between the reviews
+	Interval int
+	// Due is the number of days from the export until the next review
+	Due int
+}
+
+type ExportNote struct {
+	// Guid identifies the note across exports so re-importing updates the note instead of duplicating it
+	Guid     string
+	Fields   []string
+	Tags     []string
+	Schedule *Schedule
+}
+
+type Deck struct {
+	Name     string
+	NoteType NoteType
+	Notes    []*ExportNote
+	// Media holds the files referenced by the note fields by filename
+	Media map[string][]byte
+}
+
+// Write writes the deck as an .apkg archive
+func Write(w io.Writer, deck *Deck) error {
+	tmp, err := os.CreateTemp("", "anki-*.sqlite")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	err = writeCollection(tmp.Name(), deck)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	collectionWriter, err := archive.Create("collection.anki2")
+	if err != nil {
+		return err
+	}
+	collectionFile, err := os.Open(tmp.Name())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(collectionWriter, collectionFile)
+	collectionFile.Close()
+	if err != nil {
+		return err
+	}
+
+	// Media files are stored as numbered entries, the media file maps them to the filenames
+	mapping := map[string]string{}
+	i := 0
+	for filename, data := range deck.Media {
+		entryName := strconv.Itoa(i)
+		mediaWriter, err := archive.Create(entryName)
+		if err != nil {
+			return err
+		}
+		if _, err := mediaWriter.Write(data); err != nil {
+			return err
+		}
+		mapping[entryName] = filename
+		i++
+	}
+	mediaWriter, err := archive.Create("media")
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(mediaWriter).Encode(mapping); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func writeCollection(filename string, deck *Deck) error {
+	db, err := sql.Open("sqlite3", filename)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(schema); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	// Review due dates are counted in days from the collection creation
+	year, month, day := now.Date()
+	created := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	deckId := now.UnixMilli()
+	modelId := deckId + 1
+
+	models, decks, dconf, conf, err := collectionConfig(deck, deckId, modelId, now.Unix())
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		"INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')",
+		created.Unix(), now.UnixMilli(), now.UnixMilli(), conf, models, decks, dconf,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i, note := range deck.Notes {
+		noteId := now.UnixMilli() + int64(i)
+		sortField := ""
+		if len(note.Fields) > 0 {
+			sortField = PlainText(note.Fields[0])
+		}
+		tags := ""
+		if len(note.Tags) > 0 {
+			tags = " " + strings.Join(note.Tags, " ") + " "
+		}
+		_, err = tx.Exec(
+			"INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')",
+			noteId, note.Guid, modelId, now.Unix(), tags,
+			strings.Join(note.Fields, fieldSeparator), sortField, checksum(sortField),
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		// type and queue: 0 is new, 2 is review. Due is the position for new cards and the day for reviews.
+		cardType, due, interval, factor := 0, i, 0, 0
+		if note.Schedule != nil {
+			cardType = 2
+			due = int(now.Sub(created).Hours()/24) + note.Schedule.Due
+			interval = note.Schedule.Interval
+			factor = defaultEaseFactor
+		}
+		_, err = tx.Exec(
+			"INSERT INTO cards VALUES (?, ?, ?, 0, ?, -1, ?, ?, ?, ?, ?, 0, 0, 0, 0, 0, 0, '')",
+			noteId, noteId, deckId, now.Unix(), cardType, cardType, due, interval, factor,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// checksum is the number of the first 8 hex digits of the SHA-1 of the sort field, Anki uses it to find duplicates
+func checksum(value string) uint32 {
+	sum := sha1.Sum([]byte(value))
+	return binary.BigEndian.Uint32(sum[:4])
+}
+
+func collectionConfig(deck *Deck, deckId, modelId, modified int64) (models, decks, dconf, conf string, err error) {
+	fields := []map[string]interface{}{}
+	for i, name := range deck.NoteType.Fields {
+		fields = append(fields, map[string]interface{}{
+			"name": name, "ord": i, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []string{},
+		})
+	}
+	requiredFields := []int{0}
+	modelsJson, err := json.Marshal(map[string]interface{}{
+		strconv.FormatInt(modelId, 10): map[string]interface{}{
+			"id":    modelId,
+			"name":  deck.NoteType.Name,
+			"type":  0,
+			"mod":   modified,
+			"usn":   -1,
+			"sortf": 0,
+			"did":   deckId,
+			"flds":  fields,
+			"tmpls": []map[string]interface{}{{
+				"name": "Card 1", "ord": 0, "qfmt": deck.NoteType.QuestionFormat, "afmt": deck.NoteType.AnswerFormat,
+				"did": nil, "bqfmt": "", "bafmt": "",
+			}},
+			"css":       deck.NoteType.Css,
+			"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+			"latexPost": "\\end{document}",
+			"tags":      []string{},
+			"vers":      []string{},
+			"req":       []interface{}{[]interface{}{0, "any", requiredFields}},
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	newDeck := func(id int64, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id, "name": name, "mod": modified, "usn": -1, "desc": "", "dyn": 0, "conf": 1,
+			"collapsed": false, "extendNew": 10, "extendRev": 50,
+			"lrnToday": []int{0, 0}, "revToday": []int{0, 0}, "newToday": []int{0, 0}, "timeToday": []int{0, 0},
+		}
+	}
+	decksJson, err := json.Marshal(map[string]interface{}{
+		"1":                           newDeck(1, "Default"),
+		strconv.FormatInt(deckId, 10): newDeck(deckId, deck.Name),
+	})
+	if err != nil {
+		return
+	}
+
+	dconfJson, err := json.Marshal(map[string]interface{}{
+		"1": map[string]interface{}{
+			"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0,
+			"replayq": true, "dyn": false,
+			"new": map[string]interface{}{
+				"delays": []int{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": defaultEaseFactor,
+				"order": 1, "perDay": 20, "bury": true, "separate": true,
+			},
+			"rev": map[string]interface{}{
+				"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "minSpace": 1, "ivlFct": 1, "maxIvl": 36500, "bury": true,
+			},
+			"lapse": map[string]interface{}{
+				"delays": []int{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0,
+			},
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	confJson, err := json.Marshal(map[string]interface{}{
+		"nextPos": len(deck.Notes) + 1, "estTimes": true, "activeDecks": []int64{1}, "sortType": "noteFld",
+		"timeLim": 0, "sortBackwards": false, "addToCur": true, "curDeck": 1, "newBury": true, "newSpread": 0,
+		"dueCounts": true, "curModel": strconv.FormatInt(modelId, 10), "collapseTime": 1200,
+	})
+	if err != nil {
+		return
+	}
+	return string(modelsJson), string(decksJson), string(dconfJson), string(confJson), nil
+}
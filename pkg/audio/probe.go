@@ -0,0 +1,235 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var ErrUnsupportedFormat = errors.New("audio must be MP3, OGG or WAV")
+var ErrInvalidAudio = errors.New("audio file is damaged")
+
+type Format string
+
+const (
+	Format_Mp3 Format = "mp3"
+	Format_Ogg Format = "ogg"
+	Format_Wav Format = "wav"
+)
+
+func (f Format) ContentType() string {
+	switch f {
+	case Format_Mp3:
+		return "audio/mpeg"
+	case Format_Ogg:
+		return "audio/ogg"
+	case Format_Wav:
+		return "audio/wav"
+	}
+	return "application/octet-stream"
+}
+
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+type Info struct {
+	Format   Format
+	Duration time.Duration
+}
+
+// Probe detects the format of the audio from its content and measures its duration
+func Probe(data []byte) (*Info, error) {
+	var format Format
+	var duration time.Duration
+	var err error
+	switch {
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		format = Format_Wav
+		duration, err = wavDuration(data)
+	case len(data) >= 4 && string(data[0:4]) == "OggS":
+		format = Format_Ogg
+		duration, err = oggDuration(data)
+	case len(data) >= 3 && string(data[0:3]) == "ID3",
+		len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		format = Format_Mp3
+		duration, err = mp3Duration(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Info{Format: format, Duration: duration}, nil
+}
+
+// wavDuration divides the size of the data chunk by the byte rate of the fmt chunk
+func wavDuration(data []byte) (time.Duration, error) {
+	var byteRate uint32
+	offset := 12
+	for offset+8 <= len(data) {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+		switch id {
+		case "fmt ":
+			if size < 16 || body+16 > len(data) {
+				return 0, ErrInvalidAudio
+			}
+			byteRate = binary.LittleEndian.Uint32(data[body+8 : body+12])
+		case "data":
+			if byteRate == 0 {
+				return 0, ErrInvalidAudio
+			}
+			// Recorders streaming the file may leave the size unset, the rest of the file is the data
+			if size <= 0 || body+size > len(data) {
+				size = len(data) - body
+			}
+			return time.Duration(int64(size) * int64(time.Second) / int64(byteRate)), nil
+		}
+		// Chunks are padded to an even size
+		offset = body + size + size%2
+	}
+	return 0, ErrInvalidAudio
+}
+
+// oggDuration reads the sample rate from the Vorbis or Opus header and the sample count
+// from the granule position of the last page
+func oggDuration(data []byte) (time.Duration, error) {
+	var sampleRate uint32
+	var preSkip uint64
+	var lastGranule uint64
+	offset := 0
+	for offset+27 <= len(data) && string(data[offset:offset+4]) == "OggS" {
+		granule := binary.LittleEndian.Uint64(data[offset+6 : offset+14])
+		segments := int(data[offset+26])
+		if offset+27+segments > len(data) {
+			break
+		}
+		bodySize := 0
+		for _, size := range data[offset+27 : offset+27+segments] {
+			bodySize += int(size)
+		}
+		body := offset + 27 + segments
+		if body+bodySize > len(data) {
+			break
+		}
+		page := data[body : body+bodySize]
+		if sampleRate == 0 {
+			switch {
+			case len(page) >= 16 && bytes.HasPrefix(page, []byte("\x01vorbis")):
+				sampleRate = binary.LittleEndian.Uint32(page[12:16])
+			case len(page) >= 12 && bytes.HasPrefix(page, []byte("OpusHead")):
+				// Opus granule positions always count 48 kHz samples
+				sampleRate = 48000
+				preSkip = uint64(binary.LittleEndian.Uint16(page[10:12]))
+			}
+		}
+		// -1 marks a page on which no packet ends
+		if granule != ^uint64(0) {
+			lastGranule = granule
+		}
+		offset = body + bodySize
+	}
+	if sampleRate == 0 {
+		return 0, ErrInvalidAudio
+	}
+	if lastGranule < preSkip {
+		return 0, nil
+	}
+	return time.Duration((lastGranule - preSkip) * uint64(time.Second) / uint64(sampleRate)), nil
+}
+
+// Bitrates in kbit/s of MPEG-1 and MPEG-2 Layer III frames by the bitrate index of the frame header
+var mp3Bitrates = [2][16]int{
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+}
+
+var mp3SampleRates = [3]int{44100, 48000, 32000}
+
+// mp3Duration adds up the durations of the Layer III frames, the frames of variable bitrate files differ in size
+func mp3Duration(data []byte) (time.Duration, error) {
+	offset := 0
+	if len(data) >= 10 && string(data[0:3]) == "ID3" {
+		// The tag size is a syncsafe integer, 7 bits per byte
+		size := int(data[6]&0x7F)<<21 | int(data[7]&0x7F)<<14 | int(data[8]&0x7F)<<7 | int(data[9]&0x7F)
+		offset = 10 + size
+		if data[5]&0x10 != 0 {
+			offset += 10
+		}
+	}
+	var duration time.Duration
+	frames := 0
+	for offset+4 <= len(data) {
+		header := data[offset : offset+4]
+		if header[0] != 0xFF || header[1]&0xE0 != 0xE0 {
+			if frames > 0 {
+				// Trailing tags such as ID3v1 follow the last frame
+				break
+			}
+			offset++
+			continue
+		}
+		version := (header[1] >> 3) & 0x03
+		layer := (header[1] >> 1) & 0x03
+		bitrateIndex := header[2] >> 4
+		sampleRateIndex := (header[2] >> 2) & 0x03
+		padding := int((header[2] >> 1) & 0x01)
+		// Only Layer III is used for MP3 files, version 1 is reserved
+		if layer != 1 || version == 1 || sampleRateIndex == 3 || bitrateIndex == 0 || bitrateIndex == 15 {
+			if frames > 0 {
+				break
+			}
+			offset++
+			continue
+		}
+		sampleRate := mp3SampleRates[sampleRateIndex]
+		samples := 1152
+		bitrate := mp3Bitrates[0][bitrateIndex] * 1000
+		if version != 3 {
+			// MPEG-2 and MPEG-2.5 halve the sample rate and the samples per frame
+			sampleRate /= 2
+			if version == 0 {
+				sampleRate /= 2
+			}
+			samples = 576
+			bitrate = mp3Bitrates[1][bitrateIndex] * 1000
+		}
+		frameSize := samples/8*bitrate/sampleRate + padding
+		if frameSize < 4 {
+			return 0, ErrInvalidAudio
+		}
+		duration += time.Duration(int64(samples) * int64(time.Second) / int64(sampleRate))
+		frames++
+		offset += frameSize
+	}
+	if frames == 0 {
+		return 0, ErrInvalidAudio
+	}
+	return duration, nil
+}
+
+// MaxDuration is the longest audio that can be attached to a card
+const MaxDuration = time.Second * 30
+
+// MaxSize is the largest audio file that can be attached to a card
+const MaxSize = 5 << 20
+
+var ErrTooLong = errors.New("audio is longer than 30 seconds or larger than 5 MB")
+
+// Check probes the audio of a card and rejects files that are too long
+func Check(data []byte) (*Info, error) {
+	if len(data) > MaxSize {
+		return nil, ErrTooLong
+	}
+	info, err := Probe(data)
+	if err != nil {
+		return nil, err
+	}
+	if info.Duration > MaxDuration {
+		return nil, ErrTooLong
+	}
+	return info, nil
+}
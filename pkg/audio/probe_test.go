@@ -0,0 +1,244 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+// wavFile builds a WAV file with a fmt chunk of the byte rate, none when it is 0, followed by the chunks
+func wavFile(byteRate uint32, chunks ...[]byte) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("RIFF")
+	binary.Write(buf, binary.LittleEndian, uint32(0))
+	buf.WriteString("WAVE")
+	if byteRate > 0 {
+		buf.WriteString("fmt ")
+		binary.Write(buf, binary.LittleEndian, uint32(16))
+		binary.Write(buf, binary.LittleEndian, uint16(1))
+		binary.Write(buf, binary.LittleEndian, uint16(1))
+		binary.Write(buf, binary.LittleEndian, byteRate)
+		binary.Write(buf, binary.LittleEndian, byteRate)
+		binary.Write(buf, binary.LittleEndian, uint16(1))
+		binary.Write(buf, binary.LittleEndian, uint16(8))
+	}
+	for _, chunk := range chunks {
+		buf.Write(chunk)
+	}
+	return buf.Bytes()
+}
+
+// wavChunk builds a chunk declaring sizeField as its size, padded to an even size
+func wavChunk(id string, sizeField uint32, body []byte) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(id)
+	binary.Write(buf, binary.LittleEndian, sizeField)
+	buf.Write(body)
+	if len(body)%2 == 1 {
+		buf.WriteByte(0)
+	}
+	return buf.Bytes()
+}
+
+func wavData(size int) []byte {
+	return wavChunk("data", uint32(size), make([]byte, size))
+}
+
+func oggPage(granule uint64, body []byte) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("OggS")
+	buf.Write([]byte{0, 0})
+	binary.Write(buf, binary.LittleEndian, granule)
+	buf.Write(make([]byte, 12))
+	segments := []byte{}
+	for size := len(body); ; size -= 255 {
+		if size < 255 {
+			segments = append(segments, byte(size))
+			break
+		}
+		segments = append(segments, 255)
+	}
+	buf.WriteByte(byte(len(segments)))
+	buf.Write(segments)
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+func vorbisHeader(sampleRate uint32) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("\x01vorbis")
+	binary.Write(buf, binary.LittleEndian, uint32(0))
+	buf.WriteByte(1)
+	binary.Write(buf, binary.LittleEndian, sampleRate)
+	buf.Write(make([]byte, 14))
+	return buf.Bytes()
+}
+
+func opusHeader(preSkip uint16) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("OpusHead")
+	buf.Write([]byte{1, 1})
+	binary.Write(buf, binary.LittleEndian, preSkip)
+	buf.Write(make([]byte, 7))
+	return buf.Bytes()
+}
+
+func join(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+// mp3Frames builds frames of the header, sized as the header declares
+func mp3Frames(header []byte, size, count int) []byte {
+	frame := make([]byte, size)
+	copy(frame, header)
+	return bytes.Repeat(frame, count)
+}
+
+// id3Tag builds an ID3v2 tag with a body of the size
+func id3Tag(size int) []byte {
+	tag := []byte{'I', 'D', '3', 4, 0, 0, byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(tag, make([]byte, size)...)
+}
+
+// A 128 kbit/s 44.1 kHz MPEG-1 Layer III frame is 417 bytes long and lasts 1152 samples
+var mpeg1Header = []byte{0xFF, 0xFB, 0x90, 0x00}
+
+const mpeg1FrameSize = 417
+const mpeg1FrameDuration = time.Duration(1152 * int64(time.Second) / 44100)
+
+// A 64 kbit/s 22.05 kHz MPEG-2 Layer III frame is 208 bytes long and lasts 576 samples
+var mpeg2Header = []byte{0xFF, 0xF3, 0x80, 0x00}
+
+const mpeg2FrameSize = 208
+const mpeg2FrameDuration = time.Duration(576 * int64(time.Second) / 22050)
+
+func TestProbe(t *testing.T) {
+	vorbis := join(
+		oggPage(0, vorbisHeader(44100)),
+		oggPage(^uint64(0), make([]byte, 600)),
+		oggPage(44100, make([]byte, 100)),
+		oggPage(88200, make([]byte, 100)),
+	)
+	tests := []struct {
+		name         string
+		data         []byte
+		wantFormat   Format
+		wantDuration time.Duration
+		wantErr      error
+	}{
+		{name: "wav", data: wavFile(8000, wavData(8000)), wantFormat: Format_Wav, wantDuration: time.Second},
+		{
+			name:         "wav with chunk before data",
+			data:         wavFile(8000, wavChunk("LIST", 3, []byte("abc")), wavData(4000)),
+			wantFormat:   Format_Wav,
+			wantDuration: time.Millisecond * 500,
+		},
+		{
+			name:         "wav with unset data size",
+			data:         wavFile(8000, wavChunk("data", 0, make([]byte, 2000))),
+			wantFormat:   Format_Wav,
+			wantDuration: time.Millisecond * 250,
+		},
+		{
+			name:         "wav with truncated data",
+			data:         wavFile(8000, wavChunk("data", 8000, make([]byte, 4000))),
+			wantFormat:   Format_Wav,
+			wantDuration: time.Millisecond * 500,
+		},
+		{name: "wav without fmt chunk", data: wavFile(0, wavData(8000)), wantErr: ErrInvalidAudio},
+		{name: "wav without data chunk", data: wavFile(8000), wantErr: ErrInvalidAudio},
+		{name: "wav truncated in fmt chunk", data: wavFile(8000, wavData(8000))[:24], wantErr: ErrInvalidAudio},
+		{name: "ogg vorbis", data: vorbis, wantFormat: Format_Ogg, wantDuration: time.Second * 2},
+		{
+			name:         "ogg opus",
+			data:         join(oggPage(0, opusHeader(312)), oggPage(48000+312, make([]byte, 100))),
+			wantFormat:   Format_Ogg,
+			wantDuration: time.Second,
+		},
+		{
+			name:         "ogg truncated in last page",
+			data:         vorbis[:len(vorbis)-50],
+			wantFormat:   Format_Ogg,
+			wantDuration: time.Second,
+		},
+		{name: "ogg without codec header", data: oggPage(88200, make([]byte, 100)), wantErr: ErrInvalidAudio},
+		{name: "ogg truncated in first page", data: vorbis[:20], wantErr: ErrInvalidAudio},
+		{
+			name:         "mp3",
+			data:         mp3Frames(mpeg1Header, mpeg1FrameSize, 10),
+			wantFormat:   Format_Mp3,
+			wantDuration: 10 * mpeg1FrameDuration,
+		},
+		{
+			name:         "mp3 with ID3 tags",
+			data:         join(id3Tag(20), mp3Frames(mpeg1Header, mpeg1FrameSize, 10), []byte("TAG"), make([]byte, 125)),
+			wantFormat:   Format_Mp3,
+			wantDuration: 10 * mpeg1FrameDuration,
+		},
+		{
+			name:         "mp3 MPEG-2",
+			data:         mp3Frames(mpeg2Header, mpeg2FrameSize, 5),
+			wantFormat:   Format_Mp3,
+			wantDuration: 5 * mpeg2FrameDuration,
+		},
+		{name: "mp3 truncated in frame header", data: mpeg1Header[:2], wantErr: ErrInvalidAudio},
+		{name: "mp3 truncated in ID3 tag", data: id3Tag(100)[:50], wantErr: ErrInvalidAudio},
+		{name: "mp3 without Layer III frames", data: mp3Frames([]byte{0xFF, 0xFD, 0x90, 0x00}, 100, 3), wantErr: ErrInvalidAudio},
+		{name: "flac", data: []byte("fLaC\x00\x00\x00\x22"), wantErr: ErrUnsupportedFormat},
+		{name: "empty", data: []byte{}, wantErr: ErrUnsupportedFormat},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := Probe(test.data)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("got error %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("probe: %v", err)
+			}
+			if info.Format != test.wantFormat {
+				t.Errorf("got format %s, want %s", info.Format, test.wantFormat)
+			}
+			if info.Duration != test.wantDuration {
+				t.Errorf("got duration %v, want %v", info.Duration, test.wantDuration)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "short audio", data: wavFile(8000, wavData(8000))},
+		{name: "longest audio", data: wavFile(100, wavData(int(MaxDuration/time.Second)*100))},
+		{name: "too long", data: wavFile(100, wavData(int(MaxDuration/time.Second)*100+100)), wantErr: ErrTooLong},
+		{name: "too large", data: wavFile(1<<20, wavData(MaxSize)), wantErr: ErrTooLong},
+		{name: "unsupported format", data: []byte("fLaC"), wantErr: ErrUnsupportedFormat},
+		{name: "damaged", data: wavFile(0), wantErr: ErrInvalidAudio},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := Check(test.data)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("got error %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("check: %v", err)
+			}
+			if info.Format != Format_Wav {
+				t.Errorf("got format %s, want %s", info.Format, Format_Wav)
+			}
+		})
+	}
+}
@@ -7,6 +7,11 @@ import (
 	"github.com/flash-cards-vocab/backend/config"
 	cardRepo "github.com/flash-cards-vocab/backend/pkg/repository/card_repository"
 	collectionRepo "github.com/flash-cards-vocab/backend/pkg/repository/collection_repository"
+	folderRepo "github.com/flash-cards-vocab/backend/pkg/repository/folder_repository"
+	importJobRepo "github.com/flash-cards-vocab/backend/pkg/repository/import_job_repository"
+	lexemeRepo "github.com/flash-cards-vocab/backend/pkg/repository/lexeme_repository"
+	moderationRepo "github.com/flash-cards-vocab/backend/pkg/repository/moderation_repository"
+	userExportRepo "github.com/flash-cards-vocab/backend/pkg/repository/user_export_repository"
 	userRepo "github.com/flash-cards-vocab/backend/pkg/repository/user_repository"
 	gormPostgres "gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -59,12 +64,28 @@ func (m *Manager) AutoMigrate() {
 		cardRepo.CardUserProgress{},
 		cardRepo.CollectionCards{},
 		cardRepo.CollectionUserProgress{},
+		cardRepo.CardRevision{},
+		cardRepo.CardUserMetrics{},
+		cardRepo.CardRelatedWord{},
+		cardRepo.CardNote{},
+		cardRepo.CardMnemonic{},
+		cardRepo.CardMnemonicVote{},
 		collectionRepo.Collection{},
 		collectionRepo.CollectionCards{},
 		collectionRepo.CollectionMetrics{},
 		collectionRepo.CollectionUserMetrics{},
 		collectionRepo.CollectionUserProgress{},
+		collectionRepo.CollectionEvent{},
+		collectionRepo.CollectionTrending{},
+		collectionRepo.CollectionReview{},
 		userRepo.User{},
+		folderRepo.Folder{},
+		folderRepo.FolderCollections{},
+		moderationRepo.Report{},
+		moderationRepo.UserWarning{},
+		userExportRepo.UserDataExport{},
+		importJobRepo.ImportJob{},
+		lexemeRepo.Lexeme{},
 	)
 }
 
@@ -0,0 +1,70 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// Extensions of the image types, mime.ExtensionsByType lists the less common extensions first
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Download returns the content of a card image or audio and a filename with an extension matching its content.
+// Media stored in the bucket is read with the client, other URLs are fetched over http.
+func Download(client *storage.Client, bucketName, mediaUrl string) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*50)
+	defer cancel()
+
+	var reader io.ReadCloser
+	bucketPrefix := "https://storage.googleapis.com/" + bucketName + "/"
+	if strings.HasPrefix(mediaUrl, bucketPrefix) {
+		objectReader, err := client.Bucket(bucketName).Object(strings.TrimPrefix(mediaUrl, bucketPrefix)).NewReader(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		reader = objectReader
+	} else {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaUrl, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			return nil, "", err
+		}
+		if response.StatusCode != http.StatusOK {
+			response.Body.Close()
+			return nil, "", fmt.Errorf("received %d response code", response.StatusCode)
+		}
+		reader = response.Body
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := path.Base(strings.SplitN(mediaUrl, "?", 2)[0])
+	if path.Ext(filename) == "" || len(path.Ext(filename)) > 5 {
+		contentType := http.DetectContentType(data)
+		if extension, ok := imageExtensions[contentType]; ok {
+			filename += extension
+		} else if extensions, _ := mime.ExtensionsByType(contentType); len(extensions) > 0 {
+			filename += extensions[0]
+		}
+	}
+	return data, filename, nil
+}
@@ -0,0 +1,37 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/flash-cards-vocab/backend/pkg/audio"
+	"github.com/google/uuid"
+)
+
+// Card audio is stored next to the card images, under the environment prefix of the bucket
+const cardAudioLocation = "card_audio"
+
+// UploadCardAudio stores audio already checked to be of the format and returns its public URL,
+// the name is made unique so cards with the same word keep their own audio
+func UploadCardAudio(client *storage.Client, bucketName, envPrefix string, data []byte, name string, format audio.Format) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*50)
+	defer cancel()
+
+	filenameToUpload := cardAudioLocation + "/" + strings.ReplaceAll(name, " ", "+") + "--" + uuid.NewString() + format.Extension()
+	fileURL := "https://storage.googleapis.com/" + bucketName + "/" + envPrefix + "/" + filenameToUpload
+	wc := client.Bucket(bucketName).Object(envPrefix + "/" + filenameToUpload).NewWriter(ctx)
+	wc.ContentType = format.ContentType()
+
+	if _, err := io.Copy(wc, bytes.NewReader(data)); err != nil {
+		wc.Close()
+		return "", err
+	}
+	if err := wc.Close(); err != nil {
+		return "", err
+	}
+	return fileURL, nil
+}
@@ -1,6 +1,7 @@
 package card_repository
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/flash-cards-vocab/backend/entity"
@@ -8,29 +9,53 @@ import (
 )
 
 type Card struct {
-	Id         uuid.UUID  `gorm:"primary_key;column:id"`
-	Word       string     `gorm:"column:word"`
-	ImageUrl   string     `gorm:"column:image_url"`
-	Definition string     `gorm:"column:definition"`
-	Sentence   string     `gorm:"column:sentence"`
-	Antonyms   string     `gorm:"column:antonyms"`
-	Synonyms   string     `gorm:"column:synonyms"`
-	AuthorId   uuid.UUID  `gorm:"column:author_id"`
-	CreatedAt  time.Time  `gorm:"column:created_at"`
-	UpdatedAt  time.Time  `gorm:"column:updated_at"`
-	DeletedAt  *time.Time `gorm:"column:deleted_at"`
+	Id             uuid.UUID  `gorm:"primary_key;column:id"`
+	Word           string     `gorm:"column:word"`
+	ImageUrl       string     `gorm:"column:image_url"`
+	AudioUrl       string     `gorm:"column:audio_url"`
+	Definition     string     `gorm:"column:definition"`
+	Sentence       string     `gorm:"column:sentence"`
+	Antonyms       string     `gorm:"column:antonyms"`
+	Synonyms       string     `gorm:"column:synonyms"`
+	SynonymList    string     `gorm:"column:synonym_list"`
+	AntonymList    string     `gorm:"column:antonym_list"`
+	Senses         string     `gorm:"column:senses"`
+	Pronunciation  string     `gorm:"column:pronunciation"`
+	Gender         string     `gorm:"column:gender"`
+	Plural         string     `gorm:"column:plural"`
+	AuthorId       uuid.UUID  `gorm:"column:author_id"`
+	SourceLanguage string     `gorm:"column:source_language"`
+	TargetLanguage string     `gorm:"column:target_language"`
+	Level          string     `gorm:"column:level"`
+	Hidden         bool       `gorm:"column:hidden"`
+	LexemeId       *uuid.UUID `gorm:"column:lexeme_id"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+	DeletedAt      *time.Time `gorm:"column:deleted_at"`
 }
 
 func (c *Card) ToEntity() *entity.Card {
 	return &entity.Card{
-		Id:         c.Id,
-		Word:       c.Word,
-		ImageUrl:   c.ImageUrl,
-		Definition: c.Definition,
-		Sentence:   c.Sentence,
-		Antonyms:   c.Antonyms,
-		Synonyms:   c.Synonyms,
-		AuthorId:   c.AuthorId,
+		Id:             c.Id,
+		Word:           c.Word,
+		ImageUrl:       c.ImageUrl,
+		AudioUrl:       c.AudioUrl,
+		Definition:     c.Definition,
+		Sentence:       c.Sentence,
+		Antonyms:       c.Antonyms,
+		Synonyms:       c.Synonyms,
+		SynonymList:    relatedWordsEntity(c.SynonymList, c.Synonyms),
+		AntonymList:    relatedWordsEntity(c.AntonymList, c.Antonyms),
+		Senses:         sensesEntity(c.Senses),
+		Pronunciation:  c.Pronunciation,
+		Gender:         c.Gender,
+		Plural:         c.Plural,
+		AuthorId:       c.AuthorId,
+		SourceLanguage: c.SourceLanguage,
+		TargetLanguage: c.TargetLanguage,
+		Level:          c.Level,
+		LexemeId:       c.LexemeId,
+		Hidden:         c.Hidden,
 	}
 }
 
@@ -38,42 +63,80 @@ func (c Card) ToArrayEntity(cards []*Card) []*entity.Card {
 	res := []*entity.Card{}
 	for _, card := range cards {
 		res = append(res, &entity.Card{
-			Id:         card.Id,
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			Synonyms:   card.Synonyms,
-			AuthorId:   card.AuthorId,
+			Id:             card.Id,
+			Word:           card.Word,
+			ImageUrl:       card.ImageUrl,
+			AudioUrl:       card.AudioUrl,
+			Definition:     card.Definition,
+			Sentence:       card.Sentence,
+			Antonyms:       card.Antonyms,
+			Synonyms:       card.Synonyms,
+			SynonymList:    relatedWordsEntity(card.SynonymList, card.Synonyms),
+			AntonymList:    relatedWordsEntity(card.AntonymList, card.Antonyms),
+			Senses:         sensesEntity(card.Senses),
+			Pronunciation:  card.Pronunciation,
+			Gender:         card.Gender,
+			Plural:         card.Plural,
+			AuthorId:       card.AuthorId,
+			SourceLanguage: card.SourceLanguage,
+			TargetLanguage: card.TargetLanguage,
+			Level:          card.Level,
 		})
 	}
 	return res
 }
 
 type CardWithOccurence struct {
-	Id         uuid.UUID `gorm:"primary_key;column:id"`
-	Word       string    `gorm:"column:word"`
-	ImageUrl   string    `gorm:"column:image_url"`
-	Definition string    `gorm:"column:definition"`
-	Sentence   string    `gorm:"column:sentence"`
-	Antonyms   string    `gorm:"column:antonyms"`
-	Synonyms   string    `gorm:"column:synonyms"`
-	AuthorId   uuid.UUID `gorm:"column:author_id"`
-	Occurence  int       `gorm:"column:occurence"`
+	Id             uuid.UUID `gorm:"primary_key;column:id"`
+	Word           string    `gorm:"column:word"`
+	ImageUrl       string    `gorm:"column:image_url"`
+	AudioUrl       string    `gorm:"column:audio_url"`
+	Definition     string    `gorm:"column:definition"`
+	Sentence       string    `gorm:"column:sentence"`
+	Antonyms       string    `gorm:"column:antonyms"`
+	Synonyms       string    `gorm:"column:synonyms"`
+	SynonymList    string    `gorm:"column:synonym_list"`
+	AntonymList    string    `gorm:"column:antonym_list"`
+	Senses         string    `gorm:"column:senses"`
+	Pronunciation  string    `gorm:"column:pronunciation"`
+	Gender         string    `gorm:"column:gender"`
+	Plural         string    `gorm:"column:plural"`
+	AuthorId       uuid.UUID `gorm:"column:author_id"`
+	SourceLanguage string    `gorm:"column:source_language"`
+	TargetLanguage string    `gorm:"column:target_language"`
+	Level          string    `gorm:"column:level"`
+	Occurence      int       `gorm:"column:occurence"`
+	Note           string    `gorm:"column:note"`
+	Mnemonic       string    `gorm:"column:mnemonic"`
+	Likes          uint32    `gorm:"column:likes"`
+	Dislikes       uint32    `gorm:"column:dislikes"`
 }
 
 func (c *CardWithOccurence) ToEntity() *entity.CardWithOccurence {
 	return &entity.CardWithOccurence{
-		Id:         c.Id,
-		Word:       c.Word,
-		ImageUrl:   c.ImageUrl,
-		Definition: c.Definition,
-		Sentence:   c.Sentence,
-		Antonyms:   c.Antonyms,
-		Synonyms:   c.Synonyms,
-		AuthorId:   c.AuthorId,
-		Occurence:  c.Occurence,
+		Id:             c.Id,
+		Word:           c.Word,
+		ImageUrl:       c.ImageUrl,
+		AudioUrl:       c.AudioUrl,
+		Definition:     c.Definition,
+		Sentence:       c.Sentence,
+		Antonyms:       c.Antonyms,
+		Synonyms:       c.Synonyms,
+		SynonymList:    relatedWordsEntity(c.SynonymList, c.Synonyms),
+		AntonymList:    relatedWordsEntity(c.AntonymList, c.Antonyms),
+		Senses:         sensesEntity(c.Senses),
+		Pronunciation:  c.Pronunciation,
+		Gender:         c.Gender,
+		Plural:         c.Plural,
+		AuthorId:       c.AuthorId,
+		SourceLanguage: c.SourceLanguage,
+		TargetLanguage: c.TargetLanguage,
+		Level:          c.Level,
+		Occurence:      c.Occurence,
+		Note:           c.Note,
+		Mnemonic:       c.Mnemonic,
+		Likes:          c.Likes,
+		Dislikes:       c.Dislikes,
 	}
 }
 
@@ -81,15 +144,29 @@ func (c CardWithOccurence) ToArrayEntity(cards []*CardWithOccurence) []*entity.C
 	res := []*entity.CardWithOccurence{}
 	for _, card := range cards {
 		res = append(res, &entity.CardWithOccurence{
-			Id:         card.Id,
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			Synonyms:   card.Synonyms,
-			AuthorId:   card.AuthorId,
-			Occurence:  card.Occurence,
+			Id:             card.Id,
+			Word:           card.Word,
+			ImageUrl:       card.ImageUrl,
+			AudioUrl:       card.AudioUrl,
+			Definition:     card.Definition,
+			Sentence:       card.Sentence,
+			Antonyms:       card.Antonyms,
+			Synonyms:       card.Synonyms,
+			SynonymList:    relatedWordsEntity(card.SynonymList, card.Synonyms),
+			AntonymList:    relatedWordsEntity(card.AntonymList, card.Antonyms),
+			Senses:         sensesEntity(card.Senses),
+			Pronunciation:  card.Pronunciation,
+			Gender:         card.Gender,
+			Plural:         card.Plural,
+			AuthorId:       card.AuthorId,
+			SourceLanguage: card.SourceLanguage,
+			TargetLanguage: card.TargetLanguage,
+			Level:          card.Level,
+			Occurence:      card.Occurence,
+			Note:           card.Note,
+			Mnemonic:       card.Mnemonic,
+			Likes:          card.Likes,
+			Dislikes:       card.Dislikes,
 		})
 	}
 	return res
@@ -134,6 +211,96 @@ func (c *CardMetrics) ToEntity() *entity.CardMetrics {
 	}
 }
 
+type CardUserMetrics struct {
+	Id        uuid.UUID  `gorm:"primary_key;column:id"`
+	CardId    uuid.UUID  `gorm:"column:card_id"`
+	UserId    uuid.UUID  `gorm:"column:user_id"`
+	Liked     bool       `gorm:"column:liked"`
+	Disliked  bool       `gorm:"column:disliked"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UpdatedAt time.Time  `gorm:"column:updated_at"`
+	DeletedAt *time.Time `gorm:"column:deleted_at"`
+}
+
+func (c *CardUserMetrics) ToEntity() *entity.CardUserMetrics {
+	return &entity.CardUserMetrics{
+		Id:       c.Id,
+		UserId:   c.UserId,
+		CardId:   c.CardId,
+		Liked:    c.Liked,
+		Disliked: c.Disliked,
+	}
+}
+
+type CardNote struct {
+	Id        uuid.UUID  `gorm:"primary_key;column:id"`
+	CardId    uuid.UUID  `gorm:"column:card_id"`
+	UserId    uuid.UUID  `gorm:"column:user_id"`
+	Note      string     `gorm:"column:note"`
+	Mnemonic  string     `gorm:"column:mnemonic"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UpdatedAt time.Time  `gorm:"column:updated_at"`
+	DeletedAt *time.Time `gorm:"column:deleted_at"`
+}
+
+func (c *CardNote) ToEntity() *entity.CardNote {
+	return &entity.CardNote{
+		Id:        c.Id,
+		CardId:    c.CardId,
+		UserId:    c.UserId,
+		Note:      c.Note,
+		Mnemonic:  c.Mnemonic,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+type CardMnemonic struct {
+	Id        uuid.UUID  `gorm:"primary_key;column:id"`
+	CardId    uuid.UUID  `gorm:"column:card_id"`
+	AuthorId  uuid.UUID  `gorm:"column:author_id"`
+	Text      string     `gorm:"column:text"`
+	Upvotes   uint32     `gorm:"column:upvotes"`
+	Downvotes uint32     `gorm:"column:downvotes"`
+	Hidden    bool       `gorm:"column:hidden"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UpdatedAt time.Time  `gorm:"column:updated_at"`
+	DeletedAt *time.Time `gorm:"column:deleted_at"`
+}
+
+type CardMnemonicForUser struct {
+	Id        uuid.UUID `gorm:"column:id"`
+	CardId    uuid.UUID `gorm:"column:card_id"`
+	AuthorId  uuid.UUID `gorm:"column:author_id"`
+	Text      string    `gorm:"column:text"`
+	Upvotes   uint32    `gorm:"column:upvotes"`
+	Downvotes uint32    `gorm:"column:downvotes"`
+	Vote      int       `gorm:"column:vote"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (c *CardMnemonicForUser) ToEntity() *entity.CardMnemonic {
+	return &entity.CardMnemonic{
+		Id:        c.Id,
+		CardId:    c.CardId,
+		AuthorId:  c.AuthorId,
+		Text:      c.Text,
+		Upvotes:   c.Upvotes,
+		Downvotes: c.Downvotes,
+		Vote:      c.Vote,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+type CardMnemonicVote struct {
+	Id         uuid.UUID  `gorm:"primary_key;column:id"`
+	MnemonicId uuid.UUID  `gorm:"column:mnemonic_id"`
+	UserId     uuid.UUID  `gorm:"column:user_id"`
+	Vote       int        `gorm:"column:vote"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+	DeletedAt  *time.Time `gorm:"column:deleted_at"`
+}
+
 type CollectionCards struct {
 	Id           uuid.UUID  `gorm:"primary_key;column:id"`
 	CardId       uuid.UUID  `gorm:"column:card_id"`
@@ -190,3 +357,139 @@ type UserCardsStatistics struct {
 	Reviewing    uint32 `gorm:"column:reviewing"`
 	Learning     uint32 `gorm:"column:learning"`
 }
+
+type CardRevision struct {
+	Id             uuid.UUID  `gorm:"primary_key;column:id"`
+	CardId         uuid.UUID  `gorm:"column:card_id"`
+	Revision       int        `gorm:"column:revision"`
+	Word           string     `gorm:"column:word"`
+	ImageUrl       string     `gorm:"column:image_url"`
+	AudioUrl       string     `gorm:"column:audio_url"`
+	Definition     string     `gorm:"column:definition"`
+	Sentence       string     `gorm:"column:sentence"`
+	Antonyms       string     `gorm:"column:antonyms"`
+	Synonyms       string     `gorm:"column:synonyms"`
+	SynonymList    string     `gorm:"column:synonym_list"`
+	AntonymList    string     `gorm:"column:antonym_list"`
+	Senses         string     `gorm:"column:senses"`
+	Pronunciation  string     `gorm:"column:pronunciation"`
+	Gender         string     `gorm:"column:gender"`
+	Plural         string     `gorm:"column:plural"`
+	SourceLanguage string     `gorm:"column:source_language"`
+	TargetLanguage string     `gorm:"column:target_language"`
+	Level          string     `gorm:"column:level"`
+	EditorId       uuid.UUID  `gorm:"column:editor_id"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+	DeletedAt      *time.Time `gorm:"column:deleted_at"`
+}
+
+func (c *CardRevision) ToEntity() *entity.CardRevision {
+	return &entity.CardRevision{
+		Id:             c.Id,
+		CardId:         c.CardId,
+		Revision:       c.Revision,
+		Word:           c.Word,
+		ImageUrl:       c.ImageUrl,
+		AudioUrl:       c.AudioUrl,
+		Definition:     c.Definition,
+		Sentence:       c.Sentence,
+		Antonyms:       c.Antonyms,
+		Synonyms:       c.Synonyms,
+		SynonymList:    relatedWordsEntity(c.SynonymList, c.Synonyms),
+		AntonymList:    relatedWordsEntity(c.AntonymList, c.Antonyms),
+		Senses:         sensesEntity(c.Senses),
+		Pronunciation:  c.Pronunciation,
+		Gender:         c.Gender,
+		Plural:         c.Plural,
+		SourceLanguage: c.SourceLanguage,
+		TargetLanguage: c.TargetLanguage,
+		Level:          c.Level,
+		EditorId:       c.EditorId,
+		EditedAt:       c.CreatedAt,
+	}
+}
+
+// sensesEntity reads the senses stored as JSON, a card without senses stores an empty string
+func sensesEntity(column string) []*entity.CardSense {
+	if column == "" {
+		return nil
+	}
+	senses := []*entity.CardSense{}
+	if err := json.Unmarshal([]byte(column), &senses); err != nil {
+		return nil
+	}
+	return senses
+}
+
+func sensesColumn(senses []*entity.CardSense) string {
+	if len(senses) == 0 {
+		return ""
+	}
+	column, err := json.Marshal(senses)
+	if err != nil {
+		return ""
+	}
+	return string(column)
+}
+
+// relatedWordsEntity reads the synonyms or antonyms stored as JSON, cards stored before the lists
+// and by old clients only have the flat text
+func relatedWordsEntity(column, flat string) []*entity.RelatedWord {
+	if column == "" {
+		return entity.ParseRelatedWords(flat, nil)
+	}
+	words := []*entity.RelatedWord{}
+	if err := json.Unmarshal([]byte(column), &words); err != nil {
+		return entity.ParseRelatedWords(flat, nil)
+	}
+	return words
+}
+
+// relatedWordsColumn stores the synonyms or antonyms as JSON, nil stores an empty string so they are read from the flat text
+func relatedWordsColumn(words []*entity.RelatedWord) string {
+	if words == nil {
+		return ""
+	}
+	column, err := json.Marshal(words)
+	if err != nil {
+		return ""
+	}
+	return string(column)
+}
+
+// CardRelatedWord is a synonym or antonym of a card, indexed by the card it links to
+type CardRelatedWord struct {
+	Id            uuid.UUID           `gorm:"primary_key;column:id"`
+	CardId        uuid.UUID           `gorm:"column:card_id"`
+	RelatedCardId *uuid.UUID          `gorm:"column:related_card_id"`
+	Word          string              `gorm:"column:word"`
+	Relation      entity.CardRelation `gorm:"column:relation"`
+	CreatedAt     time.Time           `gorm:"column:created_at"`
+	UpdatedAt     time.Time           `gorm:"column:updated_at"`
+}
+
+// relatedWordRows lists the synonyms and antonyms of the card as card_related_word rows
+func relatedWordRows(cardId uuid.UUID, synonyms, antonyms []*entity.RelatedWord) []*CardRelatedWord {
+	rows := []*CardRelatedWord{}
+	for _, relation := range []struct {
+		words    []*entity.RelatedWord
+		relation entity.CardRelation
+	}{
+		{synonyms, entity.CardRelation_Synonym},
+		{antonyms, entity.CardRelation_Antonym},
+	} {
+		for _, word := range relation.words {
+			rows = append(rows, &CardRelatedWord{
+				Id:            uuid.New(),
+				CardId:        cardId,
+				RelatedCardId: word.CardId,
+				Word:          word.Word,
+				Relation:      relation.relation,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+			})
+		}
+	}
+	return rows
+}
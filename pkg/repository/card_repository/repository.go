@@ -2,13 +2,16 @@ package card_repository
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
 	"github.com/flash-cards-vocab/backend/entity"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type repository struct {
@@ -21,21 +24,37 @@ func New(db *gorm.DB) repositoryIntf.CardRepository {
 }
 
 func (r *repository) CreateSingleCard(card entity.Card) error {
-	return r.db.
-		Table(r.tableName).
-		Create(&Card{
-			Id:         uuid.New(),
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			Synonyms:   card.Synonyms,
-			AuthorId:   card.AuthorId,
-			CreatedAt:  time.Now(),
-			UpdatedAt:  time.Now(),
-		}).
-		Error
+	tx := r.db.Begin()
+	cardModel := Card{
+		Id:            uuid.New(),
+		Word:          card.Word,
+		ImageUrl:      card.ImageUrl,
+		AudioUrl:      card.AudioUrl,
+		Definition:    card.Definition,
+		Sentence:      card.Sentence,
+		Antonyms:      card.Antonyms,
+		Synonyms:      card.Synonyms,
+		SynonymList:   relatedWordsColumn(card.SynonymList),
+		AntonymList:   relatedWordsColumn(card.AntonymList),
+		Senses:        sensesColumn(card.Senses),
+		Pronunciation: card.Pronunciation,
+		Gender:        card.Gender,
+		Plural:        card.Plural,
+		AuthorId:      card.AuthorId,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	err := tx.Table(r.tableName).Create(&cardModel).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = createRelatedWords(tx, relatedWordRows(cardModel.Id, card.SynonymList, card.AntonymList))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
 }
 
 func (r *repository) GetCardsByWord(word string, limit, offset int) ([]*entity.Card, error) {
@@ -46,7 +65,8 @@ func (r *repository) GetCardsByWord(word string, limit, offset int) ([]*entity.C
 			INNER JOIN card_metrics cm on c.id = cm.card_id
 			WHERE lower(c.word) like lower(?)
 			AND c.deleted_at IS null
-			ORDER BY cm.likes 
+			AND c.hidden = FALSE
+			ORDER BY cm.likes - cm.dislikes DESC
 			LIMIT ?
 			OFFSET ?
 		`, "%"+word+"%", limit, offset).
@@ -59,20 +79,29 @@ func (r *repository) GetCardsByWord(word string, limit, offset int) ([]*entity.C
 	return Card{}.ToArrayEntity(cards), nil
 }
 
-func (r *repository) GetUserCardsByWord(word string, userId uuid.UUID, limit, offset int) ([]*entity.CardWithOccurence, error) {
+func (r *repository) GetUserCardsByWord(word string, userId uuid.UUID, filter entity.SearchFilter, limit, offset int) ([]*entity.CardWithOccurence, error) {
 	var cards []*CardWithOccurence
+	filterCondition, filterArgs := searchFilterCondition(filter, "c")
+	// Cards whose synonyms or private note match come after the cards whose word matches
+	pattern := "%" + word + "%"
+	args := append([]interface{}{userId, pattern, pattern, pattern, pattern, userId}, filterArgs...)
+	args = append(args, pattern, limit, offset)
 	err := r.db.
 		Raw(`
-			SELECT count(cc.*) as occurence, c.* FROM card c
+			SELECT count(cc.*) as occurence, COALESCE(MAX(cm.likes), 0) as likes, COALESCE(MAX(cm.dislikes), 0) as dislikes,
+			COALESCE(MAX(cn.note), '') as note, COALESCE(MAX(cn.mnemonic), '') as mnemonic, c.* FROM card c
 			LEFT JOIN collection_cards cc on c.id = cc.card_id
-			WHERE lower(c.word) like lower(?)
+			LEFT JOIN card_metrics cm on c.id = cm.card_id AND cm.deleted_at IS null
+			LEFT JOIN card_note cn on c.id = cn.card_id AND cn.user_id = ? AND cn.deleted_at IS null
+			WHERE (lower(c.word) like lower(?) OR lower(c.synonyms) like lower(?) OR lower(cn.note) like lower(?) OR lower(cn.mnemonic) like lower(?))
 			AND c.author_id = ?
 			AND c.deleted_at IS null
+			AND `+filterCondition+`
 			GROUP BY c.id
-			ORDER BY occurence desc
+			ORDER BY lower(c.word) like lower(?) desc, occurence desc
 			LIMIT ?
 			OFFSET ?
-		`, "%"+word+"%", userId, limit, offset).
+		`, args...).
 		Scan(&cards).
 		Error
 	if err != nil {
@@ -82,20 +111,62 @@ func (r *repository) GetUserCardsByWord(word string, userId uuid.UUID, limit, of
 	return CardWithOccurence{}.ToArrayEntity(cards), nil
 }
 
-func (r *repository) GetGlobalCardsByWord(word string, userId uuid.UUID, limit, offset int) ([]*entity.CardWithOccurence, error) {
+func (r *repository) GetGlobalCardsByWord(word string, userId uuid.UUID, filter entity.SearchFilter, limit, offset int) ([]*entity.CardWithOccurence, error) {
 	var cards []*CardWithOccurence
+	filterCondition, filterArgs := searchFilterCondition(filter, "c")
+	// Cards whose synonyms or private note match come after the cards whose word matches
+	pattern := "%" + word + "%"
+	args := append([]interface{}{userId, pattern, pattern, pattern, pattern, userId}, filterArgs...)
+	args = append(args, pattern, limit, offset)
 	err := r.db.
 		Raw(`
-			SELECT count(cc.*) as occurence, c.* FROM card c
+			SELECT count(cc.*) as occurence, COALESCE(MAX(cm.likes), 0) as likes, COALESCE(MAX(cm.dislikes), 0) as dislikes,
+			COALESCE(MAX(cn.note), '') as note, COALESCE(MAX(cn.mnemonic), '') as mnemonic, c.* FROM card c
 			LEFT JOIN collection_cards cc on c.id = cc.card_id
-			WHERE lower(c.word) like lower(?)
+			LEFT JOIN card_metrics cm on c.id = cm.card_id AND cm.deleted_at IS null
+			LEFT JOIN card_note cn on c.id = cn.card_id AND cn.user_id = ? AND cn.deleted_at IS null
+			WHERE (lower(c.word) like lower(?) OR lower(c.synonyms) like lower(?) OR lower(cn.note) like lower(?) OR lower(cn.mnemonic) like lower(?))
 			AND c.author_id <> ?
 			AND c.deleted_at IS null
+			AND c.hidden = FALSE
+			AND `+filterCondition+`
 			GROUP BY c.id
-			ORDER BY occurence desc
+			ORDER BY lower(c.word) like lower(?) desc, COALESCE(MAX(cm.likes), 0) - COALESCE(MAX(cm.dislikes), 0) desc, occurence desc
 			LIMIT ?
 			OFFSET ?
-		`, "%"+word+"%", userId, limit, offset).
+		`, args...).
+		Scan(&cards).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return CardWithOccurence{}.ToArrayEntity(cards), nil
+}
+
+// GetSimilarCards returns the visible cards of the lexeme and the cards of the same word and language not linked
+// to a lexeme yet, the canonical card first and then the best rated and most used ones
+func (r *repository) GetSimilarCards(word, language string, lexemeId, canonicalCardId uuid.UUID, limit int) ([]*entity.CardWithOccurence, error) {
+	var cards []*CardWithOccurence
+	err := r.db.
+		Raw(`
+			SELECT count(cc.*) as occurence, COALESCE(MAX(cm.likes), 0) as likes, COALESCE(MAX(cm.dislikes), 0) as dislikes, c.* FROM card c
+			LEFT JOIN collection_cards cc on c.id = cc.card_id AND cc.deleted_at IS null
+			LEFT JOIN card_metrics cm on c.id = cm.card_id AND cm.deleted_at IS null
+			WHERE (
+				c.lexeme_id = ?
+				OR (
+					c.lexeme_id IS null
+					AND lower(regexp_replace(trim(c.word), '\s+', ' ', 'g')) = ?
+					AND (lower(c.source_language) = ? OR lower(c.source_language) like ?)
+				)
+			)
+			AND c.deleted_at IS null
+			AND c.hidden = FALSE
+			GROUP BY c.id
+			ORDER BY c.id = ? desc, COALESCE(MAX(cm.likes), 0) - COALESCE(MAX(cm.dislikes), 0) desc, occurence desc, c.created_at asc
+			LIMIT ?
+		`, lexemeId, word, language, language+"-%", canonicalCardId, limit).
 		Scan(&cards).
 		Error
 	if err != nil {
@@ -139,21 +210,54 @@ func (r *repository) GetUserCardsStatistics(userId uuid.UUID) (*entity.UserCardS
 	}, nil
 }
 
+// GetAuthorCardsByWords returns the cards of the author with one of the words, the words are compared
+// lowercased and trimmed
+func (r *repository) GetAuthorCardsByWords(authorId uuid.UUID, words []string) ([]*entity.Card, error) {
+	datas := []*Card{}
+	if len(words) == 0 {
+		return []*entity.Card{}, nil
+	}
+	err := r.db.
+		Table(r.tableName).
+		Where("author_id = ? AND LOWER(TRIM(word)) IN ? AND deleted_at IS NULL", authorId, words).
+		Order("created_at").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.Card{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
 func (r *repository) CreateMultipleCards(collectionId uuid.UUID, cards []*entity.Card, userId uuid.UUID) error {
 	tx := r.db.Begin()
 	cardsModels := []*Card{}
 	for _, card := range cards {
 		cardsModels = append(cardsModels, &Card{
-			Id:         uuid.New(),
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			AuthorId:   userId,
-			Synonyms:   card.Synonyms,
-			CreatedAt:  time.Now(),
-			UpdatedAt:  time.Now(),
+			Id:             uuid.New(),
+			Word:           card.Word,
+			ImageUrl:       card.ImageUrl,
+			AudioUrl:       card.AudioUrl,
+			Definition:     card.Definition,
+			Sentence:       card.Sentence,
+			Antonyms:       card.Antonyms,
+			AuthorId:       userId,
+			Synonyms:       card.Synonyms,
+			SynonymList:    relatedWordsColumn(card.SynonymList),
+			AntonymList:    relatedWordsColumn(card.AntonymList),
+			Senses:         sensesColumn(card.Senses),
+			Pronunciation:  card.Pronunciation,
+			Gender:         card.Gender,
+			Plural:         card.Plural,
+			SourceLanguage: card.SourceLanguage,
+			TargetLanguage: card.TargetLanguage,
+			Level:          card.Level,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
 		})
 	}
 
@@ -162,6 +266,15 @@ func (r *repository) CreateMultipleCards(collectionId uuid.UUID, cards []*entity
 		tx.Rollback()
 		return err
 	}
+	relatedWords := []*CardRelatedWord{}
+	for i, card := range cards {
+		relatedWords = append(relatedWords, relatedWordRows(cardsModels[i].Id, card.SynonymList, card.AntonymList)...)
+	}
+	err = createRelatedWords(r.db, relatedWords)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
 
 	cardUserProgress := []*CardUserProgress{}
 	for _, card := range cardsModels {
@@ -541,3 +654,798 @@ func (r *repository) DontKnowCard(collectionId, cardId, userId uuid.UUID) error
 	tx.Commit()
 	return nil
 }
+
+// searchFilterCondition builds the card search condition, it matches language tags the same way as collection search
+func searchFilterCondition(filter entity.SearchFilter, alias string) (string, []interface{}) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	languageColumns := [][2]string{
+		{"source_language", filter.SourceLanguage},
+		{"target_language", filter.TargetLanguage},
+	}
+	for _, languageColumn := range languageColumns {
+		column, tag := languageColumn[0], languageColumn[1]
+		if tag == "" {
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf("(%[1]s.%[2]s = ? OR %[1]s.%[2]s LIKE ?)", alias, column))
+		args = append(args, tag, tag+"-%")
+	}
+	if filter.Level != "" {
+		conditions = append(conditions, fmt.Sprintf("lower(%s.level) = lower(?)", alias))
+		args = append(args, filter.Level)
+	}
+	if filter.PartOfSpeech != "" {
+		// The senses are stored as JSON written by sensesColumn
+		conditions = append(conditions, fmt.Sprintf("%s.senses LIKE ?", alias))
+		args = append(args, `%"partOfSpeech":"`+string(filter.PartOfSpeech)+`"%`)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+func (r *repository) GetCardById(id uuid.UUID) (*entity.Card, error) {
+	data := Card{}
+	err := r.db.
+		Table(r.tableName).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrCardNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+// IsCardInCollection tells whether the card is linked to the collection
+func (r *repository) IsCardInCollection(collectionId, cardId uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.
+		Table("collection_cards").
+		Where("collection_id = ? AND card_id = ? AND deleted_at IS NULL", collectionId, cardId).
+		Count(&count).
+		Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// IsCardSharedWithOtherAuthors tells whether the card is in a collection of another author
+func (r *repository) IsCardSharedWithOtherAuthors(cardId, authorId uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.
+		Table("collection_cards AS cc").
+		Joins("INNER JOIN collection ON collection.id = cc.collection_id").
+		Where("cc.card_id = ? AND collection.author_id <> ? AND cc.deleted_at IS NULL AND collection.deleted_at IS NULL", cardId, authorId).
+		Count(&count).
+		Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UpdateCard edits the card in place and keeps its previous content as a revision,
+// the learning progress stays with the card
+func (r *repository) UpdateCard(card entity.Card, editorId uuid.UUID) error {
+	tx := r.db.Begin()
+	current := Card{}
+	err := tx.
+		Table(r.tableName).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NULL", card.Id).
+		First(&current).
+		Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repositoryIntf.ErrCardNotFound
+		}
+		return err
+	}
+	var lastRevision int
+	err = tx.
+		Table("card_revision").
+		Select("COALESCE(MAX(revision), 0)").
+		Where("card_id = ?", card.Id).
+		Scan(&lastRevision).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.
+		Table("card_revision").
+		Create(&CardRevision{
+			Id:             uuid.New(),
+			CardId:         current.Id,
+			Revision:       lastRevision + 1,
+			Word:           current.Word,
+			ImageUrl:       current.ImageUrl,
+			AudioUrl:       current.AudioUrl,
+			Definition:     current.Definition,
+			Sentence:       current.Sentence,
+			Antonyms:       current.Antonyms,
+			Synonyms:       current.Synonyms,
+			SynonymList:    current.SynonymList,
+			AntonymList:    current.AntonymList,
+			Senses:         current.Senses,
+			Pronunciation:  current.Pronunciation,
+			Gender:         current.Gender,
+			Plural:         current.Plural,
+			SourceLanguage: current.SourceLanguage,
+			TargetLanguage: current.TargetLanguage,
+			Level:          current.Level,
+			EditorId:       editorId,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	updates := map[string]interface{}{
+		"word":            card.Word,
+		"image_url":       card.ImageUrl,
+		"audio_url":       card.AudioUrl,
+		"definition":      card.Definition,
+		"sentence":        card.Sentence,
+		"antonyms":        card.Antonyms,
+		"synonyms":        card.Synonyms,
+		"synonym_list":    relatedWordsColumn(card.SynonymList),
+		"antonym_list":    relatedWordsColumn(card.AntonymList),
+		"senses":          sensesColumn(card.Senses),
+		"pronunciation":   card.Pronunciation,
+		"gender":          card.Gender,
+		"plural":          card.Plural,
+		"source_language": card.SourceLanguage,
+		"target_language": card.TargetLanguage,
+		"level":           card.Level,
+		"updated_at":      time.Now(),
+	}
+	// The clustering job links the card again once its word or language changes
+	if entity.NormalizeLexemeWord(current.Word) != entity.NormalizeLexemeWord(card.Word) ||
+		entity.LexemeLanguage(current.SourceLanguage) != entity.LexemeLanguage(card.SourceLanguage) {
+		updates["lexeme_id"] = nil
+	}
+	err = tx.
+		Table(r.tableName).
+		Where("id = ?", card.Id).
+		Updates(updates).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.
+		Table("card_related_word").
+		Where("card_id = ?", card.Id).
+		Delete(&CardRelatedWord{}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = createRelatedWords(tx, relatedWordRows(card.Id, card.SynonymList, card.AntonymList))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// ForkCard replaces the card in the collection with an edited copy authored by card.AuthorId,
+// the learning progress of the learners of the collection is copied to the new card
+func (r *repository) ForkCard(collectionId, cardId uuid.UUID, card entity.Card) (*entity.Card, error) {
+	tx := r.db.Begin()
+	forkModel := Card{
+		Id:             uuid.New(),
+		Word:           card.Word,
+		ImageUrl:       card.ImageUrl,
+		AudioUrl:       card.AudioUrl,
+		Definition:     card.Definition,
+		Sentence:       card.Sentence,
+		Antonyms:       card.Antonyms,
+		Synonyms:       card.Synonyms,
+		SynonymList:    relatedWordsColumn(card.SynonymList),
+		AntonymList:    relatedWordsColumn(card.AntonymList),
+		Senses:         sensesColumn(card.Senses),
+		Pronunciation:  card.Pronunciation,
+		Gender:         card.Gender,
+		Plural:         card.Plural,
+		AuthorId:       card.AuthorId,
+		SourceLanguage: card.SourceLanguage,
+		TargetLanguage: card.TargetLanguage,
+		Level:          card.Level,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	err := tx.Table(r.tableName).Create(&forkModel).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = createRelatedWords(tx, relatedWordRows(forkModel.Id, card.SynonymList, card.AntonymList))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.
+		Table("card_metrics").
+		Create(&CardMetrics{
+			Id:        uuid.New(),
+			CardId:    forkModel.Id,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	progress := []*CardUserProgress{}
+	err = tx.
+		Table("card_user_progress").
+		Where(`card_id = ? AND deleted_at IS NULL AND user_id IN (
+			SELECT user_id FROM collection_user_progress WHERE collection_id = ? AND deleted_at IS NULL
+		)`, cardId, collectionId).
+		Find(&progress).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for _, p := range progress {
+		p.Id = uuid.New()
+		p.CardId = forkModel.Id
+		p.CreatedAt = time.Now()
+		p.UpdatedAt = time.Now()
+	}
+	if len(progress) > 0 {
+		err = tx.Table("card_user_progress").Create(progress).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	res := tx.
+		Table("collection_cards").
+		Where("collection_id = ? AND card_id = ? AND deleted_at IS NULL", collectionId, cardId).
+		Updates(map[string]interface{}{
+			"deleted_at": time.Now(),
+		})
+	if res.Error != nil {
+		tx.Rollback()
+		return nil, res.Error
+	}
+	// The fork only replaces a card of the collection
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return nil, repositoryIntf.ErrCardNotFound
+	}
+	err = tx.
+		Table("collection_cards").
+		Create(&CollectionCards{
+			Id:           uuid.New(),
+			CardId:       forkModel.Id,
+			CollectionId: collectionId,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+	return forkModel.ToEntity(), nil
+}
+
+func (r *repository) GetCardRevisions(cardId uuid.UUID) ([]*entity.CardRevision, error) {
+	datas := []*CardRevision{}
+	err := r.db.
+		Table("card_revision").
+		Where("card_id = ? AND deleted_at IS NULL", cardId).
+		Order("revision DESC").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CardRevision{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+// GetCollectionCardsWithoutAudio returns the cards of the collection authored by authorId that have no audio
+func (r *repository) GetCollectionCardsWithoutAudio(collectionId, authorId uuid.UUID) ([]*entity.Card, error) {
+	datas := []*Card{}
+	err := r.db.
+		Table(r.tableName).
+		Select("card.*").
+		Joins("INNER JOIN collection_cards cc ON cc.card_id = card.id AND cc.deleted_at IS NULL").
+		Where("cc.collection_id = ? AND card.author_id = ? AND card.audio_url = '' AND card.deleted_at IS NULL", collectionId, authorId).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return Card{}.ToArrayEntity(datas), nil
+}
+
+// SetCardAudio attaches generated audio to the card, it is not an edit of the card content so no revision is kept
+func (r *repository) SetCardAudio(id uuid.UUID, audioUrl string) error {
+	return r.db.
+		Table(r.tableName).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"audio_url":  audioUrl,
+			"updated_at": time.Now(),
+		}).
+		Error
+}
+
+// GetCardsByIds returns the cards that are not deleted or hidden by moderation
+func (r *repository) GetCardsByIds(ids []uuid.UUID) ([]*entity.Card, error) {
+	if len(ids) == 0 {
+		return []*entity.Card{}, nil
+	}
+	datas := []*Card{}
+	err := r.db.
+		Table(r.tableName).
+		Where("id IN ? AND hidden = FALSE AND deleted_at IS NULL", ids).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return Card{}.ToArrayEntity(datas), nil
+}
+
+// GetCardsLinkingTo returns the cards whose synonyms or antonyms link to one of the cards
+func (r *repository) GetCardsLinkingTo(ids []uuid.UUID) ([]*entity.Card, error) {
+	if len(ids) == 0 {
+		return []*entity.Card{}, nil
+	}
+	datas := []*Card{}
+	err := r.db.
+		Table(r.tableName).
+		Where(`id IN (
+			SELECT card_id FROM card_related_word WHERE related_card_id IN ?
+		) AND hidden = FALSE AND deleted_at IS NULL`, ids).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return Card{}.ToArrayEntity(datas), nil
+}
+
+func (r *repository) GetCardMetrics(id uuid.UUID) (*entity.CardMetrics, error) {
+	metrics := CardMetrics{}
+	err := r.db.
+		Table("card_metrics").
+		Where("card_id = ? AND deleted_at IS null", id).
+		First(&metrics).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &entity.CardMetrics{CardId: id}, nil
+		}
+		return nil, err
+	}
+	return metrics.ToEntity(), nil
+}
+
+// GetCardUserMetrics returns the interactions of the user with the card, none when the user never interacted
+func (r *repository) GetCardUserMetrics(id, userId uuid.UUID) (*entity.CardUserMetrics, error) {
+	metrics := CardUserMetrics{}
+	err := r.db.
+		Table("card_user_metrics").
+		Where("card_id = ? AND user_id = ? AND deleted_at IS null", id, userId).
+		First(&metrics).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &entity.CardUserMetrics{CardId: id, UserId: userId}, nil
+		}
+		return nil, err
+	}
+	return metrics.ToEntity(), nil
+}
+
+// ToggleCardLike removes the like of the user when the card is liked, otherwise likes the card and removes its dislike
+func (r *repository) ToggleCardLike(id, userId uuid.UUID) error {
+	return r.cardInteraction(id, userId, true)
+}
+
+// ToggleCardDislike removes the dislike of the user when the card is disliked, otherwise dislikes the card and removes its like
+func (r *repository) ToggleCardDislike(id, userId uuid.UUID) error {
+	return r.cardInteraction(id, userId, false)
+}
+
+// cardInteraction toggles the like or the dislike of the user and moves the counters of the card metrics
+// in one transaction. The interactions of the user are locked, so concurrent toggles apply one after another.
+func (r *repository) cardInteraction(id, userId uuid.UUID, like bool) error {
+	tx := r.db.Begin()
+	// The unique index keeps one row per user and card, the row is created before it is locked
+	err := tx.
+		Table("card_user_metrics").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&CardUserMetrics{
+			Id:        uuid.New(),
+			CardId:    id,
+			UserId:    userId,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	current := CardUserMetrics{}
+	err = tx.
+		Table("card_user_metrics").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("card_id = ? AND user_id = ? AND deleted_at IS null", id, userId).
+		First(&current).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	liked, disliked := current.Liked, current.Disliked
+	if like {
+		liked = !liked
+		disliked = disliked && !liked
+	} else {
+		disliked = !disliked
+		liked = liked && !disliked
+	}
+	counters := map[string]interface{}{"updated_at": time.Now()}
+	if liked != current.Liked {
+		counters["likes"] = counterExpr("likes", liked)
+	}
+	if disliked != current.Disliked {
+		counters["dislikes"] = counterExpr("dislikes", disliked)
+	}
+	res := tx.
+		Table("card_metrics").
+		Where("card_id = ? AND deleted_at IS null", id).
+		Updates(counters)
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+	if res.RowsAffected == 0 && (liked || disliked) {
+		metrics := CardMetrics{Id: uuid.New(), CardId: id, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		if liked {
+			metrics.Likes = 1
+		} else {
+			metrics.Dislikes = 1
+		}
+		err = tx.Table("card_metrics").Create(&metrics).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = tx.
+		Table("card_user_metrics").
+		Where("id = ?", current.Id).
+		Updates(map[string]interface{}{
+			"liked":      liked,
+			"disliked":   disliked,
+			"updated_at": time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// counterExpr increments a counter of the card metrics or decrements it without going below zero
+func counterExpr(column string, increment bool) clause.Expr {
+	if increment {
+		return gorm.Expr(column + " + 1")
+	}
+	return gorm.Expr("GREATEST(" + column + " - 1, 0)")
+}
+
+// SaveCardNote replaces the note of the user on the card, creating it when the user has none
+func (r *repository) SaveCardNote(note entity.CardNote) (*entity.CardNote, error) {
+	res := r.db.
+		Table("card_note").
+		Where("card_id = ? AND user_id = ? AND deleted_at IS NULL", note.CardId, note.UserId).
+		Updates(map[string]interface{}{
+			"note":       note.Note,
+			"mnemonic":   note.Mnemonic,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		data := CardNote{
+			Id:        uuid.New(),
+			CardId:    note.CardId,
+			UserId:    note.UserId,
+			Note:      note.Note,
+			Mnemonic:  note.Mnemonic,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		err := r.db.Table("card_note").Create(&data).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r.GetCardNote(note.CardId, note.UserId)
+}
+
+// GetCardNote returns the note of the user on the card, an empty note when the user has none
+func (r *repository) GetCardNote(cardId, userId uuid.UUID) (*entity.CardNote, error) {
+	data := CardNote{}
+	err := r.db.
+		Table("card_note").
+		Where("card_id = ? AND user_id = ? AND deleted_at IS NULL", cardId, userId).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &entity.CardNote{CardId: cardId, UserId: userId}, nil
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+func (r *repository) DeleteCardNote(cardId, userId uuid.UUID) error {
+	return r.db.
+		Table("card_note").
+		Where("card_id = ? AND user_id = ? AND deleted_at IS NULL", cardId, userId).
+		Update("deleted_at", time.Now()).
+		Error
+}
+
+// CountUserMnemonicsSince counts the mnemonics the user published since the time, deleted ones included
+// so deleting and publishing again does not get around the limit
+func (r *repository) CountUserMnemonicsSince(userId uuid.UUID, since time.Time) (int, error) {
+	var count int64
+	err := r.db.
+		Table("card_mnemonic").
+		Where("author_id = ? AND created_at >= ?", userId, since).
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *repository) HasUserCardMnemonic(cardId, userId uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.
+		Table("card_mnemonic").
+		Where("card_id = ? AND author_id = ? AND deleted_at IS NULL", cardId, userId).
+		Count(&count).
+		Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *repository) CreateCardMnemonic(mnemonic entity.CardMnemonic) (*entity.CardMnemonic, error) {
+	data := CardMnemonic{
+		Id:        uuid.New(),
+		CardId:    mnemonic.CardId,
+		AuthorId:  mnemonic.AuthorId,
+		Text:      mnemonic.Text,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	// The unique index keeps one mnemonic per user and card when the same one is published twice at once
+	result := r.db.
+		Table("card_mnemonic").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&data)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, repositoryIntf.ErrCardMnemonicExists
+	}
+	return r.GetCardMnemonic(data.Id, mnemonic.AuthorId)
+}
+
+// cardMnemonicsForUser selects the visible mnemonics with the vote of the user
+func (r *repository) cardMnemonicsForUser(userId uuid.UUID) *gorm.DB {
+	return r.db.
+		Table("card_mnemonic AS cmn").
+		Select("cmn.*, COALESCE(cmv.vote, 0) AS vote").
+		Joins("LEFT JOIN card_mnemonic_vote AS cmv ON cmv.mnemonic_id = cmn.id AND cmv.user_id = ? AND cmv.deleted_at IS NULL", userId).
+		Where("cmn.hidden = FALSE AND cmn.deleted_at IS NULL")
+}
+
+func (r *repository) GetCardMnemonic(id, userId uuid.UUID) (*entity.CardMnemonic, error) {
+	data := CardMnemonicForUser{}
+	err := r.cardMnemonicsForUser(userId).
+		Where("cmn.id = ?", id).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrCardMnemonicNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+// GetCardMnemonics returns the mnemonics of the card, the best voted first
+func (r *repository) GetCardMnemonics(cardId, userId uuid.UUID, limit, offset int) ([]*entity.CardMnemonic, int, error) {
+	var total int64
+	err := r.db.
+		Table("card_mnemonic").
+		Where("card_id = ? AND hidden = FALSE AND deleted_at IS NULL", cardId).
+		Count(&total).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+	datas := []*CardMnemonicForUser{}
+	err = r.cardMnemonicsForUser(userId).
+		Where("cmn.card_id = ?", cardId).
+		Order("cmn.upvotes - cmn.downvotes DESC, cmn.created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := []*entity.CardMnemonic{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, int(total), nil
+}
+
+// GetTopCardMnemonics returns up to perCard best voted mnemonics of every card
+func (r *repository) GetTopCardMnemonics(cardIds []uuid.UUID, userId uuid.UUID, perCard int) ([]*entity.CardMnemonic, error) {
+	if len(cardIds) == 0 {
+		return []*entity.CardMnemonic{}, nil
+	}
+	datas := []*CardMnemonicForUser{}
+	err := r.db.
+		Raw(`
+			SELECT * FROM (
+				SELECT cmn.*, COALESCE(cmv.vote, 0) AS vote,
+				ROW_NUMBER() OVER (PARTITION BY cmn.card_id ORDER BY cmn.upvotes - cmn.downvotes DESC, cmn.created_at ASC) AS position
+				FROM card_mnemonic cmn
+				LEFT JOIN card_mnemonic_vote cmv ON cmv.mnemonic_id = cmn.id AND cmv.user_id = ? AND cmv.deleted_at IS NULL
+				WHERE cmn.card_id IN ?
+				AND cmn.hidden = FALSE
+				AND cmn.deleted_at IS NULL
+			) ranked
+			WHERE position <= ?
+			ORDER BY card_id, position
+		`, userId, cardIds, perCard).
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CardMnemonic{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+// VoteCardMnemonic sets the vote of the user on the mnemonic, 1 upvotes, -1 downvotes and 0 removes the vote.
+// The counters of the mnemonic follow the change of the vote in the same transaction.
+func (r *repository) VoteCardMnemonic(id, userId uuid.UUID, vote int) error {
+	tx := r.db.Begin()
+	current := CardMnemonicVote{}
+	err := tx.
+		Table("card_mnemonic_vote").
+		Where("mnemonic_id = ? AND user_id = ? AND deleted_at IS NULL", id, userId).
+		First(&current).
+		Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return err
+	}
+	found := err == nil
+	if found && current.Vote == vote {
+		tx.Rollback()
+		return nil
+	}
+
+	counters := map[string]interface{}{"updated_at": time.Now()}
+	upvotes, downvotes := 0, 0
+	if current.Vote == 1 {
+		upvotes--
+	} else if current.Vote == -1 {
+		downvotes--
+	}
+	if vote == 1 {
+		upvotes++
+	} else if vote == -1 {
+		downvotes++
+	}
+	if upvotes != 0 {
+		counters["upvotes"] = gorm.Expr("GREATEST(upvotes + ?, 0)", upvotes)
+	}
+	if downvotes != 0 {
+		counters["downvotes"] = gorm.Expr("GREATEST(downvotes + ?, 0)", downvotes)
+	}
+	err = tx.
+		Table("card_mnemonic").
+		Where("id = ?", id).
+		Updates(counters).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if found {
+		err = tx.
+			Table("card_mnemonic_vote").
+			Where("id = ?", current.Id).
+			Updates(map[string]interface{}{
+				"vote":       vote,
+				"updated_at": time.Now(),
+			}).
+			Error
+	} else {
+		err = tx.Table("card_mnemonic_vote").Create(&CardMnemonicVote{
+			Id:         uuid.New(),
+			MnemonicId: id,
+			UserId:     userId,
+			Vote:       vote,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}).Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (r *repository) DeleteCardMnemonic(id uuid.UUID) error {
+	return r.db.
+		Table("card_mnemonic").
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", time.Now()).
+		Error
+}
+
+// createRelatedWords indexes the synonyms and antonyms of created or updated cards
+func createRelatedWords(db *gorm.DB, words []*CardRelatedWord) error {
+	if len(words) == 0 {
+		return nil
+	}
+	return db.Table("card_related_word").Create(words).Error
+}
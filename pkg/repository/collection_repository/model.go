@@ -1,6 +1,7 @@
 package collection_repository
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/flash-cards-vocab/backend/entity"
@@ -9,22 +10,30 @@ import (
 )
 
 type Collection struct {
-	Id        uuid.UUID      `gorm:"primary_key;column:id"`
-	Name      string         `gorm:"column:name"`
-	AuthorId  uuid.UUID      `gorm:"column:author_id"`
-	Topics    pq.StringArray `gorm:"type:text[];column:topics"`
-	CreatedAt time.Time      `gorm:"column:created_at"`
-	UpdatedAt time.Time      `gorm:"column:updated_at"`
-	DeletedAt *time.Time     `gorm:"column:deleted_at"`
+	Id             uuid.UUID      `gorm:"primary_key;column:id"`
+	Name           string         `gorm:"column:name"`
+	AuthorId       uuid.UUID      `gorm:"column:author_id"`
+	SourceLanguage string         `gorm:"column:source_language"`
+	TargetLanguage string         `gorm:"column:target_language"`
+	Level          string         `gorm:"column:level"`
+	Topics         pq.StringArray `gorm:"type:text[];column:topics"`
+	Hidden         bool           `gorm:"column:hidden"`
+	CreatedAt      time.Time      `gorm:"column:created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at"`
+	DeletedAt      *time.Time     `gorm:"column:deleted_at"`
 }
 
 func (c *Collection) ToEntity() *entity.Collection {
 	return &entity.Collection{
-		Id:        c.Id,
-		Name:      c.Name,
-		Topics:    c.Topics,
-		AuthorId:  c.AuthorId,
-		CreatedAt: c.CreatedAt,
+		Id:             c.Id,
+		Name:           c.Name,
+		Topics:         c.Topics,
+		AuthorId:       c.AuthorId,
+		SourceLanguage: c.SourceLanguage,
+		TargetLanguage: c.TargetLanguage,
+		Level:          c.Level,
+		Hidden:         c.Hidden,
+		CreatedAt:      c.CreatedAt,
 	}
 }
 
@@ -82,6 +91,8 @@ type CollectionMetrics struct {
 	Likes        uint32     `gorm:"column:likes"`
 	Dislikes     uint32     `gorm:"column:dislikes"`
 	Views        uint32     `gorm:"column:views"`
+	Ratings      uint32     `gorm:"column:ratings"`
+	RatingSum    uint32     `gorm:"column:rating_sum"`
 	CreatedAt    time.Time  `gorm:"column:created_at"`
 	UpdatedAt    time.Time  `gorm:"column:updated_at"`
 	DeletedAt    *time.Time `gorm:"column:deleted_at"`
@@ -89,12 +100,21 @@ type CollectionMetrics struct {
 
 func (c *CollectionMetrics) ToEntity() *entity.CollectionMetrics {
 	return &entity.CollectionMetrics{
-		Id:           c.Id,
-		CollectionId: c.CollectionId,
-		Likes:        c.Likes,
-		Dislikes:     c.Dislikes,
-		Views:        c.Views,
+		Id:            c.Id,
+		CollectionId:  c.CollectionId,
+		Likes:         c.Likes,
+		Dislikes:      c.Dislikes,
+		Views:         c.Views,
+		Ratings:       c.Ratings,
+		AverageRating: averageRating(c.RatingSum, c.Ratings),
+	}
+}
+
+func averageRating(ratingSum, ratings uint32) float64 {
+	if ratings == 0 {
+		return 0
 	}
+	return float64(ratingSum) / float64(ratings)
 }
 
 type CollectionCards struct {
@@ -115,29 +135,49 @@ func (c *CollectionCards) ToEntity() *entity.CollectionCards {
 }
 
 type Card struct {
-	Id         uuid.UUID  `gorm:"primary_key;column:id"`
-	Word       string     `gorm:"column:word"`
-	ImageUrl   string     `gorm:"column:image_url"`
-	Definition string     `gorm:"column:definition"`
-	Sentence   string     `gorm:"column:sentence"`
-	Antonyms   string     `gorm:"column:antonyms"`
-	Synonyms   string     `gorm:"column:synonyms"`
-	AuthorId   uuid.UUID  `gorm:"column:author_id"`
-	CreatedAt  time.Time  `gorm:"column:created_at"`
-	UpdatedAt  time.Time  `gorm:"column:updated_at"`
-	DeletedAt  *time.Time `gorm:"column:deleted_at"`
+	Id             uuid.UUID  `gorm:"primary_key;column:id"`
+	Word           string     `gorm:"column:word"`
+	ImageUrl       string     `gorm:"column:image_url"`
+	AudioUrl       string     `gorm:"column:audio_url"`
+	Definition     string     `gorm:"column:definition"`
+	Sentence       string     `gorm:"column:sentence"`
+	Antonyms       string     `gorm:"column:antonyms"`
+	Synonyms       string     `gorm:"column:synonyms"`
+	SynonymList    string     `gorm:"column:synonym_list"`
+	AntonymList    string     `gorm:"column:antonym_list"`
+	Senses         string     `gorm:"column:senses"`
+	Pronunciation  string     `gorm:"column:pronunciation"`
+	Gender         string     `gorm:"column:gender"`
+	Plural         string     `gorm:"column:plural"`
+	AuthorId       uuid.UUID  `gorm:"column:author_id"`
+	SourceLanguage string     `gorm:"column:source_language"`
+	TargetLanguage string     `gorm:"column:target_language"`
+	Level          string     `gorm:"column:level"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+	DeletedAt      *time.Time `gorm:"column:deleted_at"`
 }
 
 func (c *Card) ToEntity() *entity.Card {
 	return &entity.Card{
-		Id:         c.Id,
-		Word:       c.Word,
-		ImageUrl:   c.ImageUrl,
-		Definition: c.Definition,
-		Sentence:   c.Sentence,
-		Antonyms:   c.Antonyms,
-		Synonyms:   c.Synonyms,
-		AuthorId:   c.AuthorId,
+		Id:             c.Id,
+		Word:           c.Word,
+		ImageUrl:       c.ImageUrl,
+		AudioUrl:       c.AudioUrl,
+		Definition:     c.Definition,
+		Sentence:       c.Sentence,
+		Antonyms:       c.Antonyms,
+		Synonyms:       c.Synonyms,
+		SynonymList:    relatedWordsEntity(c.SynonymList, c.Synonyms),
+		AntonymList:    relatedWordsEntity(c.AntonymList, c.Antonyms),
+		Senses:         sensesEntity(c.Senses),
+		Pronunciation:  c.Pronunciation,
+		Gender:         c.Gender,
+		Plural:         c.Plural,
+		AuthorId:       c.AuthorId,
+		SourceLanguage: c.SourceLanguage,
+		TargetLanguage: c.TargetLanguage,
+		Level:          c.Level,
 	}
 }
 
@@ -145,42 +185,84 @@ func (c Card) ToArrayEntity(cards []*Card) []*entity.Card {
 	res := []*entity.Card{}
 	for _, card := range cards {
 		res = append(res, &entity.Card{
-			Id:         card.Id,
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			Synonyms:   card.Synonyms,
-			AuthorId:   card.AuthorId,
+			Id:             card.Id,
+			Word:           card.Word,
+			ImageUrl:       card.ImageUrl,
+			AudioUrl:       card.AudioUrl,
+			Definition:     card.Definition,
+			Sentence:       card.Sentence,
+			Antonyms:       card.Antonyms,
+			Synonyms:       card.Synonyms,
+			SynonymList:    relatedWordsEntity(card.SynonymList, card.Synonyms),
+			AntonymList:    relatedWordsEntity(card.AntonymList, card.Antonyms),
+			Senses:         sensesEntity(card.Senses),
+			Pronunciation:  card.Pronunciation,
+			Gender:         card.Gender,
+			Plural:         card.Plural,
+			AuthorId:       card.AuthorId,
+			SourceLanguage: card.SourceLanguage,
+			TargetLanguage: card.TargetLanguage,
+			Level:          card.Level,
 		})
 	}
 	return res
 }
 
 type CardForUser struct {
-	Id         uuid.UUID `gorm:"column:id"`
-	Word       string    `gorm:"column:word"`
-	ImageUrl   string    `gorm:"column:image_url"`
-	Definition string    `gorm:"column:definition"`
-	Sentence   string    `gorm:"column:sentence"`
-	Antonyms   string    `gorm:"column:antonyms"`
-	Synonyms   string    `gorm:"column:synonyms"`
-	Status     string    `gorm:"column:status"`
-	AuthorId   uuid.UUID `gorm:"column:author_id"`
+	Id             uuid.UUID `gorm:"column:id"`
+	Word           string    `gorm:"column:word"`
+	ImageUrl       string    `gorm:"column:image_url"`
+	AudioUrl       string    `gorm:"column:audio_url"`
+	Definition     string    `gorm:"column:definition"`
+	Sentence       string    `gorm:"column:sentence"`
+	Antonyms       string    `gorm:"column:antonyms"`
+	Synonyms       string    `gorm:"column:synonyms"`
+	SynonymList    string    `gorm:"column:synonym_list"`
+	AntonymList    string    `gorm:"column:antonym_list"`
+	Senses         string    `gorm:"column:senses"`
+	Pronunciation  string    `gorm:"column:pronunciation"`
+	Gender         string    `gorm:"column:gender"`
+	Plural         string    `gorm:"column:plural"`
+	Status         string    `gorm:"column:status"`
+	AuthorId       uuid.UUID `gorm:"column:author_id"`
+	SourceLanguage string    `gorm:"column:source_language"`
+	TargetLanguage string    `gorm:"column:target_language"`
+	Level          string    `gorm:"column:level"`
+	Likes          uint32    `gorm:"column:likes"`
+	Dislikes       uint32    `gorm:"column:dislikes"`
+	Liked          bool      `gorm:"column:liked"`
+	Disliked       bool      `gorm:"column:disliked"`
+	Note           string    `gorm:"column:note"`
+	Mnemonic       string    `gorm:"column:mnemonic"`
 }
 
 func (c *CardForUser) ToEntity() *entity.CardForUser {
 	return &entity.CardForUser{
-		Id:         c.Id,
-		Word:       c.Word,
-		ImageUrl:   c.ImageUrl,
-		Definition: c.Definition,
-		Sentence:   c.Sentence,
-		Antonyms:   c.Antonyms,
-		Synonyms:   c.Synonyms,
-		Status:     c.Status,
-		AuthorId:   c.AuthorId,
+		Id:             c.Id,
+		Word:           c.Word,
+		ImageUrl:       c.ImageUrl,
+		AudioUrl:       c.AudioUrl,
+		Definition:     c.Definition,
+		Sentence:       c.Sentence,
+		Antonyms:       c.Antonyms,
+		Synonyms:       c.Synonyms,
+		SynonymList:    relatedWordsEntity(c.SynonymList, c.Synonyms),
+		AntonymList:    relatedWordsEntity(c.AntonymList, c.Antonyms),
+		Senses:         sensesEntity(c.Senses),
+		Pronunciation:  c.Pronunciation,
+		Gender:         c.Gender,
+		Plural:         c.Plural,
+		Status:         c.Status,
+		AuthorId:       c.AuthorId,
+		SourceLanguage: c.SourceLanguage,
+		TargetLanguage: c.TargetLanguage,
+		Level:          c.Level,
+		Likes:          c.Likes,
+		Dislikes:       c.Dislikes,
+		Liked:          c.Liked,
+		Disliked:       c.Disliked,
+		Note:           c.Note,
+		Mnemonic:       c.Mnemonic,
 	}
 }
 
@@ -188,15 +270,31 @@ func (c CardForUser) ToArrayEntity(cards []*CardForUser) []*entity.CardForUser {
 	res := []*entity.CardForUser{}
 	for _, card := range cards {
 		res = append(res, &entity.CardForUser{
-			Id:         card.Id,
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			Synonyms:   card.Synonyms,
-			Status:     card.Status,
-			AuthorId:   c.AuthorId,
+			Id:             card.Id,
+			Word:           card.Word,
+			ImageUrl:       card.ImageUrl,
+			AudioUrl:       card.AudioUrl,
+			Definition:     card.Definition,
+			Sentence:       card.Sentence,
+			Antonyms:       card.Antonyms,
+			Synonyms:       card.Synonyms,
+			SynonymList:    relatedWordsEntity(card.SynonymList, card.Synonyms),
+			AntonymList:    relatedWordsEntity(card.AntonymList, card.Antonyms),
+			Senses:         sensesEntity(card.Senses),
+			Pronunciation:  card.Pronunciation,
+			Gender:         card.Gender,
+			Plural:         card.Plural,
+			Status:         card.Status,
+			AuthorId:       c.AuthorId,
+			SourceLanguage: card.SourceLanguage,
+			TargetLanguage: card.TargetLanguage,
+			Level:          card.Level,
+			Likes:          card.Likes,
+			Dislikes:       card.Dislikes,
+			Liked:          card.Liked,
+			Disliked:       card.Disliked,
+			Note:           card.Note,
+			Mnemonic:       card.Mnemonic,
 		})
 	}
 	return res
@@ -240,3 +338,209 @@ func (c *CardMetrics) ToEntity() *entity.CardMetrics {
 		Dislikes: c.Dislikes,
 	}
 }
+
+type CollectionEngagement struct {
+	CollectionId uuid.UUID      `gorm:"column:collection_id"`
+	Name         string         `gorm:"column:name"`
+	Topics       pq.StringArray `gorm:"type:text[];column:topics"`
+	Liked        bool           `gorm:"column:liked"`
+	Starred      bool           `gorm:"column:starred"`
+	Studied      bool           `gorm:"column:studied"`
+}
+
+func (c *CollectionEngagement) ToEntity() *entity.CollectionEngagement {
+	return &entity.CollectionEngagement{
+		CollectionId: c.CollectionId,
+		Name:         c.Name,
+		Topics:       c.Topics,
+		Liked:        c.Liked,
+		Starred:      c.Starred,
+		Studied:      c.Studied,
+	}
+}
+
+type RecommendationCandidate struct {
+	Collection
+	Ratings           uint32     `gorm:"column:ratings"`
+	RatingSum         uint32     `gorm:"column:rating_sum"`
+	TopicScore        float64    `gorm:"column:topic_score"`
+	CoEngagementScore float64    `gorm:"column:co_engagement_score"`
+	BestSourceId      *uuid.UUID `gorm:"column:best_source_id"`
+	Score             float64    `gorm:"column:score"`
+}
+
+func (c *RecommendationCandidate) ToEntity() *entity.RecommendationCandidate {
+	return &entity.RecommendationCandidate{
+		Collection:        c.Collection.ToEntity(),
+		AverageRating:     averageRating(c.RatingSum, c.Ratings),
+		TopicScore:        c.TopicScore,
+		CoEngagementScore: c.CoEngagementScore,
+		BestSourceId:      c.BestSourceId,
+		Score:             c.Score,
+	}
+}
+
+type CollectionEvent struct {
+	Id           uuid.UUID                  `gorm:"primary_key;column:id"`
+	CollectionId uuid.UUID                  `gorm:"column:collection_id"`
+	UserId       uuid.UUID                  `gorm:"column:user_id"`
+	Type         entity.CollectionEventType `gorm:"column:type"`
+	CreatedAt    time.Time                  `gorm:"column:created_at"`
+	UpdatedAt    time.Time                  `gorm:"column:updated_at"`
+	DeletedAt    *time.Time                 `gorm:"column:deleted_at"`
+}
+
+func (c *CollectionEvent) ToEntity() *entity.CollectionEvent {
+	return &entity.CollectionEvent{
+		Id:           c.Id,
+		CollectionId: c.CollectionId,
+		UserId:       c.UserId,
+		Type:         c.Type,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+type CollectionTrending struct {
+	Id           uuid.UUID             `gorm:"primary_key;column:id"`
+	CollectionId uuid.UUID             `gorm:"column:collection_id"`
+	TimeWindow   entity.TrendingWindow `gorm:"column:time_window"`
+	Score        float64               `gorm:"column:score"`
+	RefreshedAt  time.Time             `gorm:"column:refreshed_at"`
+	CreatedAt    time.Time             `gorm:"column:created_at"`
+	UpdatedAt    time.Time             `gorm:"column:updated_at"`
+	DeletedAt    *time.Time            `gorm:"column:deleted_at"`
+}
+
+type CollectionReview struct {
+	Id           uuid.UUID  `gorm:"primary_key;column:id"`
+	CollectionId uuid.UUID  `gorm:"column:collection_id"`
+	UserId       uuid.UUID  `gorm:"column:user_id"`
+	Rating       int        `gorm:"column:rating"`
+	Text         string     `gorm:"column:text"`
+	Reply        string     `gorm:"column:reply"`
+	RepliedAt    *time.Time `gorm:"column:replied_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+	DeletedAt    *time.Time `gorm:"column:deleted_at"`
+}
+
+func (c *CollectionReview) ToEntity() *entity.CollectionReview {
+	return &entity.CollectionReview{
+		Id:           c.Id,
+		CollectionId: c.CollectionId,
+		UserId:       c.UserId,
+		Rating:       c.Rating,
+		Text:         c.Text,
+		Reply:        c.Reply,
+		RepliedAt:    c.RepliedAt,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
+
+type CollectionReviewWithUser struct {
+	CollectionReview
+	Username string `gorm:"column:username"`
+}
+
+func (c *CollectionReviewWithUser) ToEntity() *entity.CollectionReview {
+	review := c.CollectionReview.ToEntity()
+	review.Username = c.Username
+	return review
+}
+
+type LevelCount struct {
+	Level string `gorm:"column:level"`
+	Count int    `gorm:"column:count"`
+}
+
+func (c *LevelCount) ToEntity() *entity.LevelCount {
+	return &entity.LevelCount{
+		Level: c.Level,
+		Count: c.Count,
+	}
+}
+
+// sensesEntity reads the senses stored as JSON, a card without senses stores an empty string
+func sensesEntity(column string) []*entity.CardSense {
+	if column == "" {
+		return nil
+	}
+	senses := []*entity.CardSense{}
+	if err := json.Unmarshal([]byte(column), &senses); err != nil {
+		return nil
+	}
+	return senses
+}
+
+func sensesColumn(senses []*entity.CardSense) string {
+	if len(senses) == 0 {
+		return ""
+	}
+	column, err := json.Marshal(senses)
+	if err != nil {
+		return ""
+	}
+	return string(column)
+}
+
+// relatedWordsEntity reads the synonyms or antonyms stored as JSON, cards stored before the lists
+// and by old clients only have the flat text
+func relatedWordsEntity(column, flat string) []*entity.RelatedWord {
+	if column == "" {
+		return entity.ParseRelatedWords(flat, nil)
+	}
+	words := []*entity.RelatedWord{}
+	if err := json.Unmarshal([]byte(column), &words); err != nil {
+		return entity.ParseRelatedWords(flat, nil)
+	}
+	return words
+}
+
+// relatedWordsColumn stores the synonyms or antonyms as JSON, nil stores an empty string so they are read from the flat text
+func relatedWordsColumn(words []*entity.RelatedWord) string {
+	if words == nil {
+		return ""
+	}
+	column, err := json.Marshal(words)
+	if err != nil {
+		return ""
+	}
+	return string(column)
+}
+
+// CardRelatedWord is a synonym or antonym of a card, indexed by the card it links to
+type CardRelatedWord struct {
+	Id            uuid.UUID           `gorm:"primary_key;column:id"`
+	CardId        uuid.UUID           `gorm:"column:card_id"`
+	RelatedCardId *uuid.UUID          `gorm:"column:related_card_id"`
+	Word          string              `gorm:"column:word"`
+	Relation      entity.CardRelation `gorm:"column:relation"`
+	CreatedAt     time.Time           `gorm:"column:created_at"`
+	UpdatedAt     time.Time           `gorm:"column:updated_at"`
+}
+
+// relatedWordRows lists the synonyms and antonyms of the card as card_related_word rows
+func relatedWordRows(cardId uuid.UUID, synonyms, antonyms []*entity.RelatedWord) []*CardRelatedWord {
+	rows := []*CardRelatedWord{}
+	for _, relation := range []struct {
+		words    []*entity.RelatedWord
+		relation entity.CardRelation
+	}{
+		{synonyms, entity.CardRelation_Synonym},
+		{antonyms, entity.CardRelation_Antonym},
+	} {
+		for _, word := range relation.words {
+			rows = append(rows, &CardRelatedWord{
+				Id:            uuid.New(),
+				CardId:        cardId,
+				RelatedCardId: word.CardId,
+				Word:          word.Word,
+				Relation:      relation.relation,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+			})
+		}
+	}
+	return rows
+}
@@ -2,11 +2,14 @@ package collection_repository
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
 	"github.com/flash-cards-vocab/backend/entity"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -62,6 +65,7 @@ func (r *repository) GetTotalCardsInCollection(collectionId uuid.UUID) (int, err
 		Where("c.deleted_at IS NULL").
 		Where("cc.deleted_at IS NULL").
 		Where("card.deleted_at IS NULL").
+		Where("card.hidden = FALSE").
 		Count(&total).
 		Error
 	if err != nil {
@@ -70,19 +74,176 @@ func (r *repository) GetTotalCardsInCollection(collectionId uuid.UUID) (int, err
 	return int(total), nil
 }
 
-func (r *repository) GetRecommendedCollectionsPreview(userId uuid.UUID, limit, offset int) ([]*entity.Collection, error) {
-	datas := []Collection{}
+// GetCollectionCardLevels counts the visible cards of the collection per level, cards without a level are not counted
+func (r *repository) GetCollectionCardLevels(collectionId uuid.UUID) ([]*entity.LevelCount, error) {
+	datas := []*LevelCount{}
 	err := r.db.
-		Table("collection").
-		Where("author_id <> ? AND deleted_at IS null", userId).
-		Limit(limit).
-		Offset(offset).
+		Table("card").
+		Select("card.level, COUNT(1) AS count").
+		Joins("INNER JOIN collection_cards AS cc ON cc.card_id = card.id").
+		Where("cc.collection_id = ?", collectionId).
+		Where("cc.deleted_at IS NULL").
+		Where("card.deleted_at IS NULL").
+		Where("card.hidden = FALSE").
+		Where("card.level <> ''").
+		Group("card.level").
+		Order("card.level").
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.LevelCount{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+// GetCollectionCardsProgress returns the progress of the user on the cards of the collection
+func (r *repository) GetCollectionCardsProgress(collectionId, userId uuid.UUID) ([]*entity.CardUserProgress, error) {
+	datas := []*CardUserProgress{}
+	err := r.db.
+		Table("card_user_progress AS cup").
+		Select("cup.*").
+		Joins("INNER JOIN collection_cards AS cc ON cc.card_id = cup.card_id").
+		Where("cc.collection_id = ? AND cc.deleted_at IS NULL", collectionId).
+		Where("cup.user_id = ? AND cup.deleted_at IS NULL", userId).
 		Find(&datas).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	resp := []*entity.Collection{}
+	resp := []*entity.CardUserProgress{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+// GetRecommendationCandidates scores every visible collection the user did not author and has not mastered
+// on its topics, its co-engagement with the source collections and its popularity, the best scored first
+func (r *repository) GetRecommendationCandidates(userId uuid.UUID, query entity.RecommendationQuery, limit, offset int, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.RecommendationCandidate, error) {
+	topics := pq.StringArray{}
+	weights := pq.Float64Array{}
+	for topic, weight := range query.TopicWeights {
+		topics = append(topics, topic)
+		weights = append(weights, weight)
+	}
+	sourceIds := query.SourceIds
+	if len(sourceIds) == 0 {
+		// IN needs at least one value, no collection has the nil id
+		sourceIds = []uuid.UUID{uuid.Nil}
+	}
+	filterCondition, filterArgs := searchFilterCondition(filter, "coll")
+	order := "score DESC, ranked.id"
+	if sort == entity.CollectionSort_Rating {
+		order = collectionOrder(sort, "ranked", "") + ", " + order
+	}
+	args := []interface{}{topics, weights, sourceIds, userId, userId, userId}
+	args = append(args, filterArgs...)
+	args = append(args, query.TopicWeight, query.CoEngagementWeight, query.PopularityWeight, limit, offset)
+
+	datas := []*RecommendationCandidate{}
+	err := r.db.
+		Raw(`
+			WITH topic_weight AS (
+				SELECT * FROM unnest(?::text[], ?::float8[]) AS tw (topic, weight)
+			),
+			co_engagement_source AS (
+				SELECT other.collection_id, src.collection_id AS source_collection_id,
+				COUNT(DISTINCT other.user_id) AS shared_users
+				FROM collection_user_metrics src
+				INNER JOIN collection_user_metrics other ON other.user_id = src.user_id AND other.collection_id <> src.collection_id
+				WHERE src.collection_id IN ?
+				AND src.user_id <> ?
+				AND (src.liked OR src.starred)
+				AND (other.liked OR other.starred)
+				AND src.deleted_at IS NULL
+				AND other.deleted_at IS NULL
+				GROUP BY other.collection_id, src.collection_id
+			),
+			co_engagement AS (
+				SELECT collection_id, SUM(shared_users)::float8 AS shared_users,
+				(array_agg(source_collection_id ORDER BY shared_users DESC))[1] AS best_source_id
+				FROM co_engagement_source
+				GROUP BY collection_id
+			),
+			scored AS (
+				SELECT coll.*,
+				COALESCE(cm.ratings, 0) AS ratings,
+				COALESCE(cm.rating_sum, 0) AS rating_sum,
+				LEAST(COALESCE((
+					SELECT SUM(tw.weight) FROM topic_weight tw
+					WHERE tw.topic IN (SELECT lower(trim(topic)) FROM unnest(coll.topics) AS topic)
+				), 0), 1) AS topic_score,
+				COALESCE(ce.shared_users, 0) AS shared_users,
+				ce.best_source_id,
+				ln(1 + GREATEST(COALESCE(cm.likes, 0) * 2 - COALESCE(cm.dislikes, 0) + COALESCE(cm.views, 0), 0)) AS popularity
+				FROM collection coll
+				LEFT JOIN collection_metrics cm ON cm.collection_id = coll.id AND cm.deleted_at IS NULL
+				LEFT JOIN collection_user_progress cup ON cup.collection_id = coll.id AND cup.user_id = ? AND cup.deleted_at IS NULL
+				LEFT JOIN co_engagement ce ON ce.collection_id = coll.id
+				WHERE coll.author_id <> ?
+				AND coll.deleted_at IS NULL
+				AND coll.hidden = FALSE
+				AND `+filterCondition+`
+				AND NOT COALESCE(cup.mastered >= NULLIF((
+					SELECT COUNT(1) FROM collection_cards cc
+					INNER JOIN card ON card.id = cc.card_id
+					WHERE cc.collection_id = coll.id
+					AND cc.deleted_at IS NULL
+					AND card.deleted_at IS NULL
+					AND card.hidden = FALSE
+				), 0), FALSE)
+			),
+			normalized AS (
+				SELECT scored.*,
+				COALESCE(shared_users / NULLIF(MAX(shared_users) OVER (), 0), 0) AS co_engagement_score,
+				COALESCE(popularity / NULLIF(MAX(popularity) OVER (), 0), 0) AS popularity_score
+				FROM scored
+			)
+			SELECT ranked.* FROM (
+				SELECT normalized.*,
+				? * topic_score + ? * co_engagement_score + ? * popularity_score AS score
+				FROM normalized
+			) AS ranked
+			ORDER BY `+order+`
+			LIMIT ?
+			OFFSET ?
+		`, args...).
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.RecommendationCandidate{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserCollectionEngagements(userId uuid.UUID) ([]*entity.CollectionEngagement, error) {
+	datas := []*CollectionEngagement{}
+	err := r.db.
+		Raw(`
+			SELECT coll.id AS collection_id, coll.name, coll.topics,
+			COALESCE(cum.liked, FALSE) AS liked,
+			COALESCE(cum.starred, FALSE) AS starred,
+			COALESCE(cup.mastered + cup.reviewing + cup.learning, 0) > 0 AS studied
+			FROM collection coll
+			LEFT JOIN collection_user_metrics cum ON cum.collection_id = coll.id AND cum.user_id = ? AND cum.deleted_at IS NULL
+			LEFT JOIN collection_user_progress cup ON cup.collection_id = coll.id AND cup.user_id = ? AND cup.deleted_at IS NULL
+			WHERE coll.deleted_at IS NULL
+			AND (cum.liked OR cum.starred OR cup.mastered + cup.reviewing + cup.learning > 0)
+		`, userId, userId).
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CollectionEngagement{}
 	for _, data := range datas {
 		resp = append(resp, data.ToEntity())
 	}
@@ -94,7 +255,7 @@ func (r *repository) GetLikedCollectionsPreview(userId uuid.UUID) ([]*entity.Col
 	err := r.db.
 		Table("collection coll").
 		Joins("INNER JOIN collection_user_metrics coll_um ON coll_um.collection_id = coll.id").
-		Where("author_id <> ? AND coll_um.liked = TRUE AND deleted_at IS null", userId).
+		Where("author_id <> ? AND coll_um.liked = TRUE AND coll.deleted_at IS null AND coll.hidden = FALSE", userId).
 		Find(&result).
 		Error
 	if err != nil {
@@ -112,7 +273,7 @@ func (r *repository) GetStarredCollectionsPreview(userId uuid.UUID) ([]*entity.C
 	err := r.db.
 		Table("collection coll").
 		Joins("INNER JOIN collection_user_metrics coll_um ON coll_um.collection_id = coll.id").
-		Where("author_id <> ? AND coll_um.starred=TRUE AND deleted_at IS null", userId).
+		Where("author_id <> ? AND coll_um.starred=TRUE AND coll.deleted_at IS null AND coll.hidden = FALSE", userId).
 		Scan(&datas).
 		Error
 	if err != nil {
@@ -387,14 +548,16 @@ func (r *repository) ViewCollection(id, userId uuid.UUID) error {
 	return nil
 }
 
-func (r *repository) SearchCollectionByName(search string, userId uuid.UUID) ([]*entity.Collection, error) {
+func (r *repository) SearchCollectionByName(search string, userId uuid.UUID, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.Collection, error) {
 
 	results := []*Collection{}
+	filterCondition, filterArgs := searchFilterCondition(filter, "coll")
 	err := r.db.
 		Table("collection coll").
 		Joins("INNER JOIN collection_metrics cm on coll.id = cm.collection_id").
-		Where("lower(coll.name) like lower(?) AND coll.author_id <> ? AND coll.deleted_at IS null", search, userId).
-		Order("cm.likes").
+		Where("lower(coll.name) like lower(?) AND coll.author_id <> ? AND coll.deleted_at IS null AND coll.hidden = FALSE", search, userId).
+		Where(filterCondition, filterArgs...).
+		Order(collectionOrder(sort, "cm", "cm.likes")).
 		Limit(20).
 		Scan(&results).
 		Error
@@ -409,15 +572,20 @@ func (r *repository) SearchCollectionByName(search string, userId uuid.UUID) ([]
 
 }
 
-func (r *repository) CreateCollectionWithCards(collection entity.Collection, cards []*entity.Card) (*entity.Collection, error) {
+// CreateCollectionWithCards creates the collection with new cards, existingCardIds are cards
+// of the author added to the collection as they are
+func (r *repository) CreateCollectionWithCards(collection entity.Collection, cards []*entity.Card, existingCardIds []uuid.UUID) (*entity.Collection, error) {
 	tx := r.db.Begin()
 	collectionModel := Collection{
-		Id:        uuid.New(),
-		Name:      collection.Name,
-		Topics:    collection.Topics,
-		AuthorId:  collection.AuthorId,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Id:             uuid.New(),
+		Name:           collection.Name,
+		Topics:         collection.Topics,
+		AuthorId:       collection.AuthorId,
+		SourceLanguage: collection.SourceLanguage,
+		TargetLanguage: collection.TargetLanguage,
+		Level:          collection.Level,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	err := r.db.Table("collection").Create(collectionModel).Error
@@ -472,57 +640,81 @@ func (r *repository) CreateCollectionWithCards(collection entity.Collection, car
 	cardsModels := []*Card{}
 	for _, card := range cards {
 		cardsModels = append(cardsModels, &Card{
-			Id:         uuid.New(),
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			AuthorId:   collectionModel.AuthorId,
-			Synonyms:   card.Synonyms,
-			CreatedAt:  time.Now(),
-			UpdatedAt:  time.Now(),
+			Id:             uuid.New(),
+			Word:           card.Word,
+			ImageUrl:       card.ImageUrl,
+			AudioUrl:       card.AudioUrl,
+			Definition:     card.Definition,
+			Sentence:       card.Sentence,
+			Antonyms:       card.Antonyms,
+			AuthorId:       collectionModel.AuthorId,
+			Synonyms:       card.Synonyms,
+			SynonymList:    relatedWordsColumn(card.SynonymList),
+			AntonymList:    relatedWordsColumn(card.AntonymList),
+			Senses:         sensesColumn(card.Senses),
+			Pronunciation:  card.Pronunciation,
+			Gender:         card.Gender,
+			Plural:         card.Plural,
+			SourceLanguage: card.SourceLanguage,
+			TargetLanguage: card.TargetLanguage,
+			Level:          card.Level,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
 		})
 	}
 
-	err = r.db.Table("card").Create(cardsModels).Error
-	if err != nil {
-		tx.Rollback()
-		return nil, err
-	}
+	// All the cards may be existing ones
+	if len(cardsModels) > 0 {
+		err = r.db.Table("card").Create(cardsModels).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		relatedWords := []*CardRelatedWord{}
+		for i, card := range cards {
+			relatedWords = append(relatedWords, relatedWordRows(cardsModels[i].Id, card.SynonymList, card.AntonymList)...)
+		}
+		if len(relatedWords) > 0 {
+			err = r.db.Table("card_related_word").Create(relatedWords).Error
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
 
-	cardUserProgress := []*CardUserProgress{}
-	for _, card := range cardsModels {
-		cardUserProgress = append(cardUserProgress, &CardUserProgress{
-			Id:            uuid.New(),
-			CardId:        card.Id,
-			UserId:        collectionModel.AuthorId,
-			Status:        entity.CardUserProgressType_None,
-			LearningCount: 0,
-			CreatedAt:     time.Now(),
-			UpdatedAt:     time.Now(),
-		})
-	}
-	err = r.db.Table("card_user_progress").Create(cardUserProgress).Error
-	if err != nil {
-		tx.Rollback()
-		return nil, err
-	}
-	cardMetrics := []*CardMetrics{}
-	for _, card := range cardsModels {
-		cardMetrics = append(cardMetrics, &CardMetrics{
-			Id:        uuid.New(),
-			CardId:    card.Id,
-			Likes:     0,
-			Dislikes:  0,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		})
-	}
-	err = r.db.Table("card_metrics").Create(cardMetrics).Error
-	if err != nil {
-		tx.Rollback()
-		return nil, err
+		cardUserProgress := []*CardUserProgress{}
+		for _, card := range cardsModels {
+			cardUserProgress = append(cardUserProgress, &CardUserProgress{
+				Id:            uuid.New(),
+				CardId:        card.Id,
+				UserId:        collectionModel.AuthorId,
+				Status:        entity.CardUserProgressType_None,
+				LearningCount: 0,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+			})
+		}
+		err = r.db.Table("card_user_progress").Create(cardUserProgress).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		cardMetrics := []*CardMetrics{}
+		for _, card := range cardsModels {
+			cardMetrics = append(cardMetrics, &CardMetrics{
+				Id:        uuid.New(),
+				CardId:    card.Id,
+				Likes:     0,
+				Dislikes:  0,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			})
+		}
+		err = r.db.Table("card_metrics").Create(cardMetrics).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 	}
 
 	collectionCards := []*CollectionCards{}
@@ -535,6 +727,18 @@ func (r *repository) CreateCollectionWithCards(collection entity.Collection, car
 			UpdatedAt:    time.Now(),
 		})
 	}
+	for _, cardId := range existingCardIds {
+		collectionCards = append(collectionCards, &CollectionCards{
+			Id:           uuid.New(),
+			CardId:       cardId,
+			CollectionId: collectionModel.Id,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		})
+	}
+	if len(collectionCards) == 0 {
+		return collectionModel.ToEntity(), nil
+	}
 	err = r.db.Table("collection_cards").Create(collectionCards).Error
 	if err != nil {
 		tx.Rollback()
@@ -626,10 +830,19 @@ func (r *repository) GetCollectionCards(collectionId, userId uuid.UUID, limit, o
 	cards := []*CardForUser{}
 	err := r.db.
 		Table("card").
-		Select("card.*").
+		Select(`card.*,
+			COALESCE(card_metrics.likes, 0) AS likes,
+			COALESCE(card_metrics.dislikes, 0) AS dislikes,
+			COALESCE(card_user_metrics.liked, FALSE) AS liked,
+			COALESCE(card_user_metrics.disliked, FALSE) AS disliked,
+			COALESCE(card_note.note, '') AS note,
+			COALESCE(card_note.mnemonic, '') AS mnemonic`).
 		Joins("INNER JOIN collection_cards ON collection_cards.card_id = card.id").
 		Joins("INNER JOIN collection ON collection_cards.collection_id = collection.id").
-		Where("collection.id = ? AND card.deleted_at IS NULL AND collection_cards.deleted_at IS NULL AND collection.deleted_at IS NULL", collectionId).
+		Joins("LEFT JOIN card_metrics ON card_metrics.card_id = card.id AND card_metrics.deleted_at IS NULL").
+		Joins("LEFT JOIN card_user_metrics ON card_user_metrics.card_id = card.id AND card_user_metrics.user_id = ? AND card_user_metrics.deleted_at IS NULL", userId).
+		Joins("LEFT JOIN card_note ON card_note.card_id = card.id AND card_note.user_id = ? AND card_note.deleted_at IS NULL", userId).
+		Where("collection.id = ? AND card.deleted_at IS NULL AND card.hidden = FALSE AND collection_cards.deleted_at IS NULL AND collection.deleted_at IS NULL", collectionId).
 		Limit(limit).
 		Offset(offset).
 		Find(&cards).
@@ -645,6 +858,7 @@ func (r *repository) GetCollectionCards(collectionId, userId uuid.UUID, limit, o
 		Joins("INNER JOIN collection ON collection_cards.collection_id = collection.id").
 		Where("collection.id = ?", collectionId).
 		Where("card.deleted_at IS NULL").
+		Where("card.hidden = FALSE").
 		Where("collection_cards.deleted_at IS NULL").
 		Where("collection.deleted_at IS NULL").
 		Count(&total).
@@ -653,15 +867,29 @@ func (r *repository) GetCollectionCards(collectionId, userId uuid.UUID, limit, o
 	res := []*entity.CardForUser{}
 	for _, card := range cards {
 		res = append(res, &entity.CardForUser{
-			Id:         card.Id,
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			Synonyms:   card.Synonyms,
-			Status:     card.Status,
-			AuthorId:   card.AuthorId,
+			Id:             card.Id,
+			Word:           card.Word,
+			ImageUrl:       card.ImageUrl,
+			AudioUrl:       card.AudioUrl,
+			Definition:     card.Definition,
+			Sentence:       card.Sentence,
+			Antonyms:       card.Antonyms,
+			Synonyms:       card.Synonyms,
+			SynonymList:    relatedWordsEntity(card.SynonymList, card.Synonyms),
+			AntonymList:    relatedWordsEntity(card.AntonymList, card.Antonyms),
+			Senses:         sensesEntity(card.Senses),
+			Pronunciation:  card.Pronunciation,
+			Gender:         card.Gender,
+			Plural:         card.Plural,
+			Status:         card.Status,
+			AuthorId:       card.AuthorId,
+			SourceLanguage: card.SourceLanguage,
+			TargetLanguage: card.TargetLanguage,
+			Level:          card.Level,
+			Likes:          card.Likes,
+			Dislikes:       card.Dislikes,
+			Liked:          card.Liked,
+			Disliked:       card.Disliked,
 		})
 	}
 
@@ -675,27 +903,79 @@ func (r *repository) GetCollectionCards(collectionId, userId uuid.UUID, limit, o
 
 }
 
+// UpdateCollection updates the collection, a changed language is also applied to the collection's cards
+// of the same author that still have the previous collection language
 func (r *repository) UpdateCollection(collection entity.Collection) error {
+	existing := Collection{}
+	err := r.db.
+		Table("collection").
+		Where("id = ? AND deleted_at is NULL", collection.Id).
+		First(&existing).
+		Error
+	if err != nil {
+		return err
+	}
+
+	tx := r.db.Begin()
 	collectionToUpd := Collection{
-		Name:      collection.Name,
-		Topics:    collection.Topics,
-		UpdatedAt: time.Now(),
+		Name:           collection.Name,
+		Topics:         collection.Topics,
+		SourceLanguage: collection.SourceLanguage,
+		TargetLanguage: collection.TargetLanguage,
+		Level:          collection.Level,
+		UpdatedAt:      time.Now(),
 	}
-	return r.db.
+	err = tx.
 		Table("collection").
 		Where("id = ? AND deleted_at is NULL", collection.Id).
 		Updates(collectionToUpd).
 		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	languageColumns := map[string][2]string{
+		"source_language": {existing.SourceLanguage, collection.SourceLanguage},
+		"target_language": {existing.TargetLanguage, collection.TargetLanguage},
+	}
+	for column, values := range languageColumns {
+		previous, current := values[0], values[1]
+		if current == "" || current == previous {
+			continue
+		}
+		err = tx.
+			Table("card").
+			Where("id IN (?)", tx.
+				Table("collection_cards").
+				Select("card_id").
+				Where("collection_id = ? AND deleted_at IS NULL", collection.Id)).
+			Where("author_id = ? AND deleted_at IS NULL", existing.AuthorId).
+			Where(column+" = ?", previous).
+			Updates(map[string]interface{}{
+				column:       current,
+				"updated_at": time.Now(),
+			}).
+			Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
 }
 
-func (r *repository) SearchCollectionByNameForUnregistered(search string) ([]*entity.Collection, error) {
+func (r *repository) SearchCollectionByNameForUnregistered(search string, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.Collection, error) {
 	datas := []*Collection{}
+	filterCondition, filterArgs := searchFilterCondition(filter, "collection")
 	err := r.db.
 		Table("collection").
 		Select("collection.*").
 		Joins("INNER JOIN collection_metrics ON collection.id = collection_metrics.collection_id").
-		Where("lower(collection.name) LIKE lower(?) AND collection.deleted_at IS NULL", "%"+search+"%").
-		Order("collection_metrics.likes").
+		Where("lower(collection.name) LIKE lower(?) AND collection.deleted_at IS NULL AND collection.hidden = FALSE", "%"+search+"%").
+		Where(filterCondition, filterArgs...).
+		Order(collectionOrder(sort, "collection_metrics", "collection_metrics.likes")).
 		Limit(10).
 		Find(&datas).
 		Error
@@ -713,12 +993,14 @@ func (r *repository) GetCollectionCardsForUnregistered(collectionId uuid.UUID, l
 	cards := []*CardForUser{}
 	err := r.db.
 		Table("card").
-		Select("card.*, card_user_progress.status").
+		Select("card.*, card_user_progress.status, COALESCE(card_metrics.likes, 0) AS likes, COALESCE(card_metrics.dislikes, 0) AS dislikes").
 		Joins("INNER JOIN collection_cards ON collection_cards.card_id = card.id").
 		Joins("INNER JOIN collection ON collection_cards.collection_id = collection.id").
 		Joins("INNER JOIN card_user_progress ON card_user_progress.card_id = card.id").
+		Joins("LEFT JOIN card_metrics ON card_metrics.card_id = card.id AND card_metrics.deleted_at IS NULL").
 		Where(`collection.id = ? 
 			AND card.deleted_at IS null 
+			AND card.hidden = FALSE
 			AND collection_cards.deleted_at IS null 
 			AND collection.deleted_at IS null 
 			AND card_user_progress.deleted_at IS null`, collectionId).
@@ -735,7 +1017,7 @@ func (r *repository) GetCollectionCardsForUnregistered(collectionId uuid.UUID, l
 		Table("card").
 		Joins("INNER JOIN collection_cards ON collection_cards.card_id = card.id").
 		Joins("INNER JOIN collection ON collection_cards.collection_id = collection.id").
-		Where("collection.id = ? AND card.deleted_at IS null AND collection_cards.deleted_at IS null AND collection.deleted_at IS null", collectionId).
+		Where("collection.id = ? AND card.deleted_at IS null AND card.hidden = FALSE AND collection_cards.deleted_at IS null AND collection.deleted_at IS null", collectionId).
 		Count(&total).
 		Error
 	if err != nil {
@@ -745,15 +1027,29 @@ func (r *repository) GetCollectionCardsForUnregistered(collectionId uuid.UUID, l
 	res := []*entity.CardForUser{}
 	for _, card := range cards {
 		res = append(res, &entity.CardForUser{
-			Id:         card.Id,
-			Word:       card.Word,
-			ImageUrl:   card.ImageUrl,
-			Definition: card.Definition,
-			Sentence:   card.Sentence,
-			Antonyms:   card.Antonyms,
-			Synonyms:   card.Synonyms,
-			Status:     card.Status,
-			AuthorId:   card.AuthorId,
+			Id:             card.Id,
+			Word:           card.Word,
+			ImageUrl:       card.ImageUrl,
+			AudioUrl:       card.AudioUrl,
+			Definition:     card.Definition,
+			Sentence:       card.Sentence,
+			Antonyms:       card.Antonyms,
+			Synonyms:       card.Synonyms,
+			SynonymList:    relatedWordsEntity(card.SynonymList, card.Synonyms),
+			AntonymList:    relatedWordsEntity(card.AntonymList, card.Antonyms),
+			Senses:         sensesEntity(card.Senses),
+			Pronunciation:  card.Pronunciation,
+			Gender:         card.Gender,
+			Plural:         card.Plural,
+			Status:         card.Status,
+			AuthorId:       card.AuthorId,
+			SourceLanguage: card.SourceLanguage,
+			TargetLanguage: card.TargetLanguage,
+			Level:          card.Level,
+			Likes:          card.Likes,
+			Dislikes:       card.Dislikes,
+			Liked:          card.Liked,
+			Disliked:       card.Disliked,
 		})
 	}
 
@@ -766,11 +1062,20 @@ func (r *repository) GetCollectionCardsForUnregistered(collectionId uuid.UUID, l
 	return data, nil
 }
 
-func (r *repository) GetRecommendedCollectionsPreviewForUnregistered(limit, offset int) ([]*entity.Collection, error) {
+func (r *repository) GetRecommendedCollectionsPreviewForUnregistered(limit, offset int, sort entity.CollectionSort, filter entity.SearchFilter) ([]*entity.Collection, error) {
 	datas := []Collection{}
-	err := r.db.
+	filterCondition, filterArgs := searchFilterCondition(filter, "collection")
+	query := r.db.
 		Table("collection").
-		Where("deleted_at IS null").
+		Select("collection.*").
+		Where("collection.deleted_at IS null AND collection.hidden = FALSE").
+		Where(filterCondition, filterArgs...)
+	if sort == entity.CollectionSort_Rating {
+		query = query.
+			Joins("LEFT JOIN collection_metrics ON collection.id = collection_metrics.collection_id AND collection_metrics.deleted_at IS null").
+			Order(collectionOrder(sort, "collection_metrics", ""))
+	}
+	err := query.
 		Limit(limit).
 		Offset(offset).
 		Find(&datas).
@@ -784,3 +1089,307 @@ func (r *repository) GetRecommendedCollectionsPreviewForUnregistered(limit, offs
 	}
 	return resp, nil
 }
+
+func (r *repository) CreateCollectionEvent(id, userId uuid.UUID, eventType entity.CollectionEventType) error {
+	event := CollectionEvent{
+		Id:           uuid.New(),
+		CollectionId: id,
+		UserId:       userId,
+		Type:         eventType,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	return r.db.
+		Table("collection_event").
+		Create(&event).
+		Error
+}
+
+// DeleteCollectionEvents removes the events of the user on the collection, so that a withdrawn like
+// stops counting for trending
+func (r *repository) DeleteCollectionEvents(id, userId uuid.UUID, eventType entity.CollectionEventType) error {
+	return r.db.
+		Table("collection_event").
+		Where("collection_id = ? AND user_id = ? AND type = ? AND deleted_at IS NULL", id, userId, eventType).
+		Update("deleted_at", time.Now()).
+		Error
+}
+
+func (r *repository) RefreshTrendingCollections(window entity.TrendingWindow) error {
+	since := time.Now().Add(-window.Duration())
+	halfLifeSeconds := window.HalfLife().Seconds()
+
+	tx := r.db.Begin()
+	err := tx.
+		Table("collection_trending").
+		Where("time_window = ?", window).
+		Delete(&CollectionTrending{}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	// Every event loses half of its weight each half-life, likes weigh more than views
+	err = tx.
+		Exec(`
+			INSERT INTO collection_trending (id, collection_id, time_window, score, refreshed_at, created_at, updated_at)
+			SELECT gen_random_uuid(), ce.collection_id, ?,
+			SUM(
+				CASE WHEN ce.type = 'like' THEN 3 ELSE 1 END
+				* POWER(0.5, EXTRACT(EPOCH FROM (NOW() - ce.created_at)) / ?)
+			),
+			NOW(), NOW(), NOW()
+			FROM collection_event ce
+			INNER JOIN collection coll ON coll.id = ce.collection_id
+			WHERE ce.created_at >= ?
+			AND ce.deleted_at IS NULL
+			AND coll.deleted_at IS NULL
+			GROUP BY ce.collection_id
+		`, window, halfLifeSeconds, since).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (r *repository) GetTrendingCollections(window entity.TrendingWindow, limit, offset int) ([]*entity.Collection, error) {
+	datas := []*Collection{}
+	err := r.db.
+		Table("collection coll").
+		Select("coll.*").
+		Joins("INNER JOIN collection_trending ct ON ct.collection_id = coll.id").
+		Where("ct.time_window = ? AND ct.deleted_at IS NULL AND coll.deleted_at IS NULL AND coll.hidden = FALSE", window).
+		Order("ct.score DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.Collection{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+// searchFilterCondition returns the WHERE condition for the filter on the table with the given alias.
+// A language filter matches the tag itself and every more specific tag, so "en" also matches "en-GB".
+func searchFilterCondition(filter entity.SearchFilter, alias string) (string, []interface{}) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	languageColumns := [][2]string{
+		{"source_language", filter.SourceLanguage},
+		{"target_language", filter.TargetLanguage},
+	}
+	for _, languageColumn := range languageColumns {
+		column, tag := languageColumn[0], languageColumn[1]
+		if tag == "" {
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf("(%[1]s.%[2]s = ? OR %[1]s.%[2]s LIKE ?)", alias, column))
+		args = append(args, tag, tag+"-%")
+	}
+	if filter.Level != "" {
+		conditions = append(conditions, fmt.Sprintf("lower(%s.level) = lower(?)", alias))
+		args = append(args, filter.Level)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// collectionOrder returns the ORDER BY clause for the requested sort, metrics is the alias of the joined collection_metrics table
+func collectionOrder(sort entity.CollectionSort, metrics string, relevance string) string {
+	if sort == entity.CollectionSort_Rating {
+		return fmt.Sprintf("COALESCE(%[1]s.rating_sum::float / NULLIF(%[1]s.ratings, 0), 0) DESC, %[1]s.ratings DESC", metrics)
+	}
+	return relevance
+}
+
+func (r *repository) GetCollectionReview(collectionId, userId uuid.UUID) (*entity.CollectionReview, error) {
+	data := CollectionReview{}
+	err := r.db.
+		Table("collection_review").
+		Where("collection_id = ? AND user_id = ? AND deleted_at IS null", collectionId, userId).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrCollectionReviewNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+func (r *repository) GetCollectionReviewById(id uuid.UUID) (*entity.CollectionReview, error) {
+	data := CollectionReview{}
+	err := r.db.
+		Table("collection_review").
+		Where("id = ? AND deleted_at IS null", id).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrCollectionReviewNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+func (r *repository) GetCollectionReviews(collectionId uuid.UUID, limit, offset int) ([]*entity.CollectionReview, int, error) {
+	var total int64
+	err := r.db.
+		Table("collection_review").
+		Where("collection_id = ? AND deleted_at IS null", collectionId).
+		Count(&total).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	datas := []*CollectionReviewWithUser{}
+	err = r.db.
+		Table("collection_review cr").
+		Select("cr.*, u.username").
+		Joins("INNER JOIN users u ON u.id = cr.user_id").
+		Where("cr.collection_id = ? AND cr.deleted_at IS null", collectionId).
+		Order("cr.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := []*entity.CollectionReview{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, int(total), nil
+}
+
+// UpsertCollectionReview creates or replaces the user's review and keeps the rating totals in collection_metrics in sync
+func (r *repository) UpsertCollectionReview(collectionId, userId uuid.UUID, rating int, text string) (*entity.CollectionReview, error) {
+	tx := r.db.Begin()
+	existing := CollectionReview{}
+	err := tx.
+		Table("collection_review").
+		Where("collection_id = ? AND user_id = ? AND deleted_at IS null", collectionId, userId).
+		First(&existing).
+		Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, err
+	}
+
+	review := existing
+	metricsUpdate := map[string]interface{}{"updated_at": time.Now()}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		review = CollectionReview{
+			Id:           uuid.New(),
+			CollectionId: collectionId,
+			UserId:       userId,
+			Rating:       rating,
+			Text:         text,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+		err = tx.Table("collection_review").Create(&review).Error
+		metricsUpdate["ratings"] = gorm.Expr("ratings + 1")
+		metricsUpdate["rating_sum"] = gorm.Expr("rating_sum + ?", rating)
+	} else {
+		review.Rating = rating
+		review.Text = text
+		review.UpdatedAt = time.Now()
+		err = tx.
+			Table("collection_review").
+			Where("id = ?", existing.Id).
+			Updates(map[string]interface{}{
+				"rating":     rating,
+				"text":       text,
+				"updated_at": review.UpdatedAt,
+			}).
+			Error
+		metricsUpdate["rating_sum"] = gorm.Expr("rating_sum + ?", rating-existing.Rating)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.
+		Table("collection_metrics").
+		Where("collection_id = ? AND deleted_at IS null", collectionId).
+		Updates(metricsUpdate).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+	return review.ToEntity(), nil
+}
+
+func (r *repository) DeleteCollectionReview(collectionId, userId uuid.UUID) error {
+	tx := r.db.Begin()
+	existing := CollectionReview{}
+	err := tx.
+		Table("collection_review").
+		Where("collection_id = ? AND user_id = ? AND deleted_at IS null", collectionId, userId).
+		First(&existing).
+		Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repositoryIntf.ErrCollectionReviewNotFound
+		}
+		return err
+	}
+
+	err = tx.
+		Table("collection_review").
+		Where("id = ?", existing.Id).
+		Update("deleted_at", time.Now()).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.
+		Table("collection_metrics").
+		Where("collection_id = ? AND deleted_at IS null", collectionId).
+		Updates(map[string]interface{}{
+			"ratings":    gorm.Expr("ratings - 1"),
+			"rating_sum": gorm.Expr("rating_sum - ?", existing.Rating),
+			"updated_at": time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *repository) ReplyToCollectionReview(id uuid.UUID, reply string) error {
+	now := time.Now()
+	return r.db.
+		Table("collection_review").
+		Where("id = ? AND deleted_at IS null", id).
+		Updates(map[string]interface{}{
+			"reply":      reply,
+			"replied_at": now,
+			"updated_at": now,
+		}).
+		Error
+}
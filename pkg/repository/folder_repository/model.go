@@ -0,0 +1,59 @@
+package folder_repository
+
+import (
+	"time"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+type Folder struct {
+	Id        uuid.UUID  `gorm:"primary_key;column:id"`
+	Name      string     `gorm:"column:name"`
+	ParentId  *uuid.UUID `gorm:"column:parent_id"`
+	UserId    uuid.UUID  `gorm:"column:user_id"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UpdatedAt time.Time  `gorm:"column:updated_at"`
+	DeletedAt *time.Time `gorm:"column:deleted_at"`
+}
+
+func (f *Folder) ToEntity() *entity.Folder {
+	return &entity.Folder{
+		Id:       f.Id,
+		Name:     f.Name,
+		ParentId: f.ParentId,
+		UserId:   f.UserId,
+	}
+}
+
+type FolderCollections struct {
+	Id           uuid.UUID  `gorm:"primary_key;column:id"`
+	FolderId     uuid.UUID  `gorm:"column:folder_id"`
+	CollectionId uuid.UUID  `gorm:"column:collection_id"`
+	UserId       uuid.UUID  `gorm:"column:user_id"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+	DeletedAt    *time.Time `gorm:"column:deleted_at"`
+}
+
+type FolderCollection struct {
+	FolderId     uuid.UUID `gorm:"column:folder_id"`
+	CollectionId uuid.UUID `gorm:"column:collection_id"`
+	Name         string    `gorm:"column:name"`
+	TotalCards   int       `gorm:"column:total_cards"`
+	Mastered     uint32    `gorm:"column:mastered"`
+	Reviewing    uint32    `gorm:"column:reviewing"`
+	Learning     uint32    `gorm:"column:learning"`
+}
+
+func (f *FolderCollection) ToEntity() *entity.FolderCollection {
+	return &entity.FolderCollection{
+		FolderId:     f.FolderId,
+		CollectionId: f.CollectionId,
+		Name:         f.Name,
+		TotalCards:   f.TotalCards,
+		Mastered:     f.Mastered,
+		Reviewing:    f.Reviewing,
+		Learning:     f.Learning,
+	}
+}
@@ -0,0 +1,218 @@
+package folder_repository
+
+import (
+	"errors"
+	"time"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db        *gorm.DB
+	tableName string
+}
+
+func New(db *gorm.DB) repositoryIntf.FolderRepository {
+	return &repository{db: db, tableName: "folder"}
+}
+
+func (r *repository) CreateFolder(folder entity.Folder) (*entity.Folder, error) {
+	folderModel := Folder{
+		Id:        uuid.New(),
+		Name:      folder.Name,
+		ParentId:  folder.ParentId,
+		UserId:    folder.UserId,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	err := r.db.Table(r.tableName).Create(&folderModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return folderModel.ToEntity(), nil
+}
+
+func (r *repository) GetFolder(id, userId uuid.UUID) (*entity.Folder, error) {
+	folder := Folder{}
+	err := r.db.
+		Table(r.tableName).
+		Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, userId).
+		First(&folder).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrFolderNotFound
+		}
+		return nil, err
+	}
+	return folder.ToEntity(), nil
+}
+
+func (r *repository) GetUserFolders(userId uuid.UUID) ([]*entity.Folder, error) {
+	datas := []*Folder{}
+	err := r.db.
+		Table(r.tableName).
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Order("name").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.Folder{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) UpdateFolder(folder entity.Folder) error {
+	return r.db.
+		Table(r.tableName).
+		Where("id = ? AND user_id = ? AND deleted_at IS NULL", folder.Id, folder.UserId).
+		Updates(map[string]interface{}{
+			"name":       folder.Name,
+			"parent_id":  folder.ParentId,
+			"updated_at": time.Now(),
+		}).
+		Error
+}
+
+// DeleteFolder removes the folder and hands its subfolders and collections over to its parent
+func (r *repository) DeleteFolder(id, userId uuid.UUID) error {
+	folder := Folder{}
+	err := r.db.
+		Table(r.tableName).
+		Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, userId).
+		First(&folder).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repositoryIntf.ErrFolderNotFound
+		}
+		return err
+	}
+
+	tx := r.db.Begin()
+	err = tx.
+		Table(r.tableName).
+		Where("parent_id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"parent_id":  folder.ParentId,
+			"updated_at": time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	assignments := tx.
+		Table("folder_collections").
+		Where("folder_id = ? AND deleted_at IS NULL", id)
+	if folder.ParentId != nil {
+		err = assignments.
+			Updates(map[string]interface{}{
+				"folder_id":  *folder.ParentId,
+				"updated_at": time.Now(),
+			}).
+			Error
+	} else {
+		err = assignments.
+			Updates(map[string]interface{}{
+				"deleted_at": time.Now(),
+			}).
+			Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.
+		Table(r.tableName).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"deleted_at": time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (r *repository) AssignCollectionToFolder(userId, collectionId uuid.UUID, folderId *uuid.UUID) error {
+	tx := r.db.Begin()
+	err := tx.
+		Table("folder_collections").
+		Where("user_id = ? AND collection_id = ? AND deleted_at IS NULL", userId, collectionId).
+		Updates(map[string]interface{}{
+			"deleted_at": time.Now(),
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	// A collection without a folder stays at the root of the tree
+	if folderId != nil {
+		err = tx.
+			Table("folder_collections").
+			Create(&FolderCollections{
+				Id:           uuid.New(),
+				FolderId:     *folderId,
+				CollectionId: collectionId,
+				UserId:       userId,
+				CreatedAt:    time.Now(),
+				UpdatedAt:    time.Now(),
+			}).
+			Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+func (r *repository) GetFolderCollections(userId uuid.UUID) ([]*entity.FolderCollection, error) {
+	datas := []*FolderCollection{}
+	err := r.db.
+		Raw(`
+			SELECT fc.folder_id, coll.id AS collection_id, coll.name,
+			COALESCE(cup.mastered, 0) AS mastered,
+			COALESCE(cup.reviewing, 0) AS reviewing,
+			COALESCE(cup.learning, 0) AS learning,
+			(
+				SELECT COUNT(1) FROM collection_cards cc
+				INNER JOIN card ON card.id = cc.card_id
+				WHERE cc.collection_id = coll.id
+				AND cc.deleted_at IS NULL
+				AND card.deleted_at IS NULL
+				AND card.hidden = FALSE
+			) AS total_cards
+			FROM folder_collections fc
+			INNER JOIN folder f ON f.id = fc.folder_id AND f.deleted_at IS NULL
+			INNER JOIN collection coll ON coll.id = fc.collection_id AND coll.deleted_at IS NULL
+			LEFT JOIN collection_user_progress cup ON cup.collection_id = coll.id AND cup.user_id = fc.user_id AND cup.deleted_at IS NULL
+			WHERE fc.user_id = ?
+			AND fc.deleted_at IS NULL
+			AND (coll.hidden = FALSE OR coll.author_id = fc.user_id)
+			ORDER BY coll.name
+		`, userId).
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.FolderCollection{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
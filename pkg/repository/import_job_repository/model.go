@@ -0,0 +1,61 @@
+package import_job_repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ImportJob struct {
+	Id              uuid.UUID      `gorm:"primary_key;column:id"`
+	UserId          uuid.UUID      `gorm:"column:user_id"`
+	Status          string         `gorm:"column:status"`
+	Filename        string         `gorm:"column:filename"`
+	DuplicatePolicy string         `gorm:"column:duplicate_policy"`
+	File            []byte         `gorm:"column:file"`
+	TotalRows       int            `gorm:"column:total_rows"`
+	ProcessedRows   int            `gorm:"column:processed_rows"`
+	FailedRows      int            `gorm:"column:failed_rows"`
+	Errors          pq.StringArray `gorm:"type:text[];column:errors"`
+	Result          string         `gorm:"column:result"`
+	CompletedAt     *time.Time     `gorm:"column:completed_at"`
+	CreatedAt       time.Time      `gorm:"column:created_at"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at"`
+	DeletedAt       *time.Time     `gorm:"column:deleted_at"`
+}
+
+func (i *ImportJob) ToEntity() *entity.ImportJob {
+	job := &entity.ImportJob{
+		Id:              i.Id,
+		UserId:          i.UserId,
+		Status:          entity.ImportJobStatus(i.Status),
+		Filename:        i.Filename,
+		DuplicatePolicy: entity.DuplicatePolicy(i.DuplicatePolicy),
+		TotalRows:       i.TotalRows,
+		ProcessedRows:   i.ProcessedRows,
+		FailedRows:      i.FailedRows,
+		Errors:          i.Errors,
+		CreatedAt:       i.CreatedAt,
+		UpdatedAt:       i.UpdatedAt,
+		CompletedAt:     i.CompletedAt,
+	}
+	if job.Errors == nil {
+		job.Errors = []string{}
+	}
+	if i.Result != "" {
+		results := []*entity.CreateMultipleCollectionResponse{}
+		if err := json.Unmarshal([]byte(i.Result), &results); err == nil {
+			job.Results = results
+		} else {
+			// Jobs finished before multi-sheet imports stored a single result
+			result := &entity.CreateMultipleCollectionResponse{}
+			if err := json.Unmarshal([]byte(i.Result), result); err == nil {
+				job.Results = []*entity.CreateMultipleCollectionResponse{result}
+			}
+		}
+	}
+	return job
+}
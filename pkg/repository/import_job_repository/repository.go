@@ -0,0 +1,151 @@
+package import_job_repository
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Columns returned when polling a job, the uploaded file is only read by the job itself
+var importJobColumns = []string{
+	"id", "user_id", "status", "filename", "duplicate_policy", "total_rows", "processed_rows", "failed_rows",
+	"errors", "result", "completed_at", "created_at", "updated_at",
+}
+
+var unfinishedImportJobStatuses = []string{
+	string(entity.ImportJobStatus_Pending),
+	string(entity.ImportJobStatus_Running),
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) repositoryIntf.ImportJobRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateImportJob(job entity.ImportJob) (*entity.ImportJob, error) {
+	jobModel := ImportJob{
+		Id:              uuid.New(),
+		UserId:          job.UserId,
+		Status:          string(entity.ImportJobStatus_Pending),
+		Filename:        job.Filename,
+		DuplicatePolicy: string(job.DuplicatePolicy),
+		File:            job.File,
+		Errors:          pq.StringArray{},
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	err := r.db.Table("import_job").Create(&jobModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return jobModel.ToEntity(), nil
+}
+
+func (r *repository) GetImportJob(id uuid.UUID) (*entity.ImportJob, error) {
+	data := ImportJob{}
+	err := r.db.
+		Table("import_job").
+		Select(importJobColumns).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrImportJobNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+func (r *repository) GetImportJobFile(id uuid.UUID) ([]byte, error) {
+	data := ImportJob{}
+	err := r.db.
+		Table("import_job").
+		Select("file").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrImportJobNotFound
+		}
+		return nil, err
+	}
+	return data.File, nil
+}
+
+func (r *repository) GetUnfinishedImportJobs() ([]*entity.ImportJob, error) {
+	datas := []*ImportJob{}
+	err := r.db.
+		Table("import_job").
+		Select(importJobColumns).
+		Where("status IN ? AND deleted_at IS NULL", unfinishedImportJobStatuses).
+		Order("created_at").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.ImportJob{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) UpdateImportJob(job entity.ImportJob) (bool, error) {
+	updates := map[string]interface{}{
+		"status":         string(job.Status),
+		"total_rows":     job.TotalRows,
+		"processed_rows": job.ProcessedRows,
+		"failed_rows":    job.FailedRows,
+		"errors":         pq.StringArray(job.Errors),
+		"completed_at":   job.CompletedAt,
+		"updated_at":     time.Now(),
+	}
+	if job.Results != nil {
+		result, err := json.Marshal(job.Results)
+		if err != nil {
+			return false, err
+		}
+		updates["result"] = string(result)
+	}
+	if job.Status.IsFinished() {
+		// The uploaded file is not needed anymore once the job is finished
+		updates["file"] = nil
+	}
+	res := r.db.
+		Table("import_job").
+		Where("id = ? AND status IN ? AND deleted_at IS NULL", job.Id, unfinishedImportJobStatuses).
+		Updates(updates)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *repository) CancelImportJob(id uuid.UUID) (bool, error) {
+	res := r.db.
+		Table("import_job").
+		Where("id = ? AND status IN ? AND deleted_at IS NULL", id, unfinishedImportJobStatuses).
+		Updates(map[string]interface{}{
+			"status":       string(entity.ImportJobStatus_Cancelled),
+			"file":         nil,
+			"completed_at": time.Now(),
+			"updated_at":   time.Now(),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,45 @@
+package lexeme_repository
+
+import (
+	"time"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+type Lexeme struct {
+	Id              uuid.UUID  `gorm:"primary_key;column:id"`
+	Word            string     `gorm:"column:word"`
+	Language        string     `gorm:"column:language"`
+	CardCount       int        `gorm:"column:card_count"`
+	CanonicalCardId *uuid.UUID `gorm:"column:canonical_card_id"`
+	CreatedAt       time.Time  `gorm:"column:created_at"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at"`
+	DeletedAt       *time.Time `gorm:"column:deleted_at"`
+}
+
+func (l *Lexeme) ToEntity() *entity.Lexeme {
+	return &entity.Lexeme{
+		Id:              l.Id,
+		Word:            l.Word,
+		Language:        l.Language,
+		CardCount:       l.CardCount,
+		CanonicalCardId: l.CanonicalCardId,
+		UpdatedAt:       l.UpdatedAt,
+	}
+}
+
+// LexemeCard is the part of a card the clustering job reads
+type LexemeCard struct {
+	Id             uuid.UUID `gorm:"column:id"`
+	Word           string    `gorm:"column:word"`
+	SourceLanguage string    `gorm:"column:source_language"`
+}
+
+func (c *LexemeCard) ToEntity() *entity.Card {
+	return &entity.Card{
+		Id:             c.Id,
+		Word:           c.Word,
+		SourceLanguage: c.SourceLanguage,
+	}
+}
@@ -0,0 +1,162 @@
+package lexeme_repository
+
+import (
+	"errors"
+	"time"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) repositoryIntf.LexemeRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetLexeme(id uuid.UUID) (*entity.Lexeme, error) {
+	data := Lexeme{}
+	err := r.db.
+		Table("lexeme").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrLexemeNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+func (r *repository) GetLexemeByWord(word, language string) (*entity.Lexeme, error) {
+	data := Lexeme{}
+	err := r.db.
+		Table("lexeme").
+		Where("word = ? AND language = ? AND deleted_at IS NULL", word, language).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrLexemeNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+// GetLexemesByWords returns the lexemes of the words in every language
+func (r *repository) GetLexemesByWords(words []string) ([]*entity.Lexeme, error) {
+	if len(words) == 0 {
+		return []*entity.Lexeme{}, nil
+	}
+	datas := []*Lexeme{}
+	err := r.db.
+		Table("lexeme").
+		Where("word IN ? AND deleted_at IS NULL", words).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.Lexeme{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+// CreateLexemes creates the lexemes and returns how many were created, a lexeme created
+// in the meantime by another run for the same word and language is skipped
+func (r *repository) CreateLexemes(lexemes []*entity.Lexeme) (int, error) {
+	if len(lexemes) == 0 {
+		return 0, nil
+	}
+	datas := []*Lexeme{}
+	for _, lexeme := range lexemes {
+		datas = append(datas, &Lexeme{
+			Id:        uuid.New(),
+			Word:      lexeme.Word,
+			Language:  lexeme.Language,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+	result := r.db.
+		Table("lexeme").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(datas)
+	return int(result.RowsAffected), result.Error
+}
+
+// GetUnlinkedCards returns the cards without a lexeme ordered by id, starting after afterId
+func (r *repository) GetUnlinkedCards(afterId uuid.UUID, limit int) ([]*entity.Card, error) {
+	datas := []*LexemeCard{}
+	err := r.db.
+		Table("card").
+		Select("id, word, source_language").
+		Where("id > ? AND lexeme_id IS NULL AND deleted_at IS NULL", afterId).
+		Order("id").
+		Limit(limit).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.Card{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+// LinkCardsToLexeme links the cards that are still without a lexeme and returns how many were linked
+func (r *repository) LinkCardsToLexeme(lexemeId uuid.UUID, cardIds []uuid.UUID) (int, error) {
+	if len(cardIds) == 0 {
+		return 0, nil
+	}
+	result := r.db.
+		Table("card").
+		Where("id IN ? AND lexeme_id IS NULL", cardIds).
+		Update("lexeme_id", lexemeId)
+	return int(result.RowsAffected), result.Error
+}
+
+// RefreshLexemes recounts the visible cards of every lexeme and picks their canonical card,
+// the best rated one, then the one in the most collections, then the oldest
+func (r *repository) RefreshLexemes() (int, error) {
+	result := r.db.
+		Exec(`
+			UPDATE lexeme l SET
+			card_count = (
+				SELECT COUNT(1) FROM card c
+				WHERE c.lexeme_id = l.id
+				AND c.hidden = FALSE
+				AND c.deleted_at IS NULL
+			),
+			canonical_card_id = (
+				SELECT c.id FROM card c
+				LEFT JOIN card_metrics cm ON cm.card_id = c.id AND cm.deleted_at IS NULL
+				WHERE c.lexeme_id = l.id
+				AND c.hidden = FALSE
+				AND c.deleted_at IS NULL
+				ORDER BY COALESCE(cm.likes, 0) - COALESCE(cm.dislikes, 0) DESC,
+				(
+					SELECT COUNT(1) FROM collection_cards cc
+					WHERE cc.card_id = c.id
+					AND cc.deleted_at IS NULL
+				) DESC,
+				c.created_at ASC
+				LIMIT 1
+			),
+			updated_at = ?
+			WHERE l.deleted_at IS NULL
+		`, time.Now())
+	return int(result.RowsAffected), result.Error
+}
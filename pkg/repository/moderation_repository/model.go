@@ -0,0 +1,83 @@
+package moderation_repository
+
+import (
+	"time"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+)
+
+type Report struct {
+	Id          uuid.UUID  `gorm:"primary_key;column:id"`
+	ReporterId  uuid.UUID  `gorm:"column:reporter_id"`
+	TargetType  string     `gorm:"column:target_type"`
+	TargetId    uuid.UUID  `gorm:"column:target_id"`
+	AuthorId    uuid.UUID  `gorm:"column:author_id"`
+	Reason      string     `gorm:"column:reason"`
+	Details     string     `gorm:"column:details"`
+	Status      string     `gorm:"column:status"`
+	Action      string     `gorm:"column:action"`
+	ModeratorId *uuid.UUID `gorm:"column:moderator_id"`
+	Note        string     `gorm:"column:note"`
+	ResolvedAt  *time.Time `gorm:"column:resolved_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+	DeletedAt   *time.Time `gorm:"column:deleted_at"`
+}
+
+func (r *Report) ToEntity() *entity.Report {
+	return &entity.Report{
+		Id:          r.Id,
+		ReporterId:  r.ReporterId,
+		TargetType:  entity.ReportTargetType(r.TargetType),
+		TargetId:    r.TargetId,
+		AuthorId:    r.AuthorId,
+		Reason:      entity.ReportReason(r.Reason),
+		Details:     r.Details,
+		Status:      entity.ReportStatus(r.Status),
+		Action:      entity.ModerationAction(r.Action),
+		ModeratorId: r.ModeratorId,
+		Note:        r.Note,
+		ResolvedAt:  r.ResolvedAt,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+type ReportWithTarget struct {
+	Report
+	TargetName    string `gorm:"column:target_name"`
+	TargetReports int    `gorm:"column:target_reports"`
+}
+
+func (r *ReportWithTarget) ToEntity() *entity.Report {
+	report := r.Report.ToEntity()
+	report.TargetName = r.TargetName
+	report.TargetReports = r.TargetReports
+	return report
+}
+
+type UserWarning struct {
+	Id         uuid.UUID  `gorm:"primary_key;column:id"`
+	UserId     uuid.UUID  `gorm:"column:user_id"`
+	ReportId   uuid.UUID  `gorm:"column:report_id"`
+	TargetType string     `gorm:"column:target_type"`
+	TargetId   uuid.UUID  `gorm:"column:target_id"`
+	Reason     string     `gorm:"column:reason"`
+	Note       string     `gorm:"column:note"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+	DeletedAt  *time.Time `gorm:"column:deleted_at"`
+}
+
+func (w *UserWarning) ToEntity() *entity.UserWarning {
+	return &entity.UserWarning{
+		Id:         w.Id,
+		UserId:     w.UserId,
+		ReportId:   w.ReportId,
+		TargetType: entity.ReportTargetType(w.TargetType),
+		TargetId:   w.TargetId,
+		Reason:     entity.ReportReason(w.Reason),
+		Note:       w.Note,
+		CreatedAt:  w.CreatedAt,
+	}
+}
@@ -0,0 +1,230 @@
+package moderation_repository
+
+import (
+	"errors"
+	"time"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) repositoryIntf.ModerationRepository {
+	return &repository{db: db}
+}
+
+// Reported collections and cards live in the table named after their target type
+func targetTable(targetType entity.ReportTargetType) string {
+	switch targetType {
+	case entity.ReportTargetType_Card:
+		return "card"
+	case entity.ReportTargetType_Mnemonic:
+		return "card_mnemonic"
+	}
+	return "collection"
+}
+
+func (r *repository) GetReportTargetAuthor(targetType entity.ReportTargetType, targetId uuid.UUID) (uuid.UUID, error) {
+	var authorIds []uuid.UUID
+	err := r.db.
+		Table(targetTable(targetType)).
+		Where("id = ? AND deleted_at IS NULL", targetId).
+		Pluck("author_id", &authorIds).
+		Error
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(authorIds) == 0 {
+		return uuid.Nil, repositoryIntf.ErrReportTargetNotFound
+	}
+	return authorIds[0], nil
+}
+
+func (r *repository) HasOpenReport(reporterId uuid.UUID, targetType entity.ReportTargetType, targetId uuid.UUID) (bool, error) {
+	var total int64
+	err := r.db.
+		Table("report").
+		Where("reporter_id = ? AND target_type = ? AND target_id = ? AND status = ? AND deleted_at IS NULL",
+			reporterId, targetType, targetId, entity.ReportStatus_Open).
+		Count(&total).
+		Error
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+func (r *repository) CreateReport(report entity.Report) (*entity.Report, error) {
+	reportModel := Report{
+		Id:         uuid.New(),
+		ReporterId: report.ReporterId,
+		TargetType: string(report.TargetType),
+		TargetId:   report.TargetId,
+		AuthorId:   report.AuthorId,
+		Reason:     string(report.Reason),
+		Details:    report.Details,
+		Status:     string(entity.ReportStatus_Open),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	err := r.db.Table("report").Create(&reportModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return reportModel.ToEntity(), nil
+}
+
+func (r *repository) GetReport(id uuid.UUID) (*entity.Report, error) {
+	data := Report{}
+	err := r.db.
+		Table("report").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrReportNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+// GetReports returns the moderation queue, targets with the most open reports first
+func (r *repository) GetReports(status entity.ReportStatus, limit, offset int) ([]*entity.Report, int, error) {
+	var total int64
+	err := r.db.
+		Table("report").
+		Where("status = ? AND deleted_at IS NULL", status).
+		Count(&total).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	datas := []*ReportWithTarget{}
+	err = r.db.
+		Raw(`
+			SELECT r.*,
+			COALESCE(coll.name, card.word, mn.text, '') AS target_name,
+			(
+				SELECT COUNT(1) FROM report other
+				WHERE other.target_type = r.target_type
+				AND other.target_id = r.target_id
+				AND other.status = ?
+				AND other.deleted_at IS NULL
+			) AS target_reports
+			FROM report r
+			LEFT JOIN collection coll ON r.target_type = ? AND coll.id = r.target_id
+			LEFT JOIN card ON r.target_type = ? AND card.id = r.target_id
+			LEFT JOIN card_mnemonic mn ON r.target_type = ? AND mn.id = r.target_id
+			WHERE r.status = ?
+			AND r.deleted_at IS NULL
+			ORDER BY target_reports DESC, r.created_at ASC
+			LIMIT ?
+			OFFSET ?
+		`, entity.ReportStatus_Open, entity.ReportTargetType_Collection, entity.ReportTargetType_Card, entity.ReportTargetType_Mnemonic, status, limit, offset).
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := []*entity.Report{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, int(total), nil
+}
+
+// ApplyModerationAction applies the action to the reported content and resolves every open report against it
+func (r *repository) ApplyModerationAction(report entity.Report, action entity.ModerationAction, moderatorId uuid.UUID, note string) error {
+	now := time.Now()
+	table := targetTable(report.TargetType)
+	tx := r.db.Begin()
+
+	var err error
+	switch action {
+	case entity.ModerationAction_Hide:
+		err = tx.
+			Table(table).
+			Where("id = ?", report.TargetId).
+			Updates(map[string]interface{}{
+				"hidden":     true,
+				"updated_at": now,
+			}).
+			Error
+	case entity.ModerationAction_Delete:
+		err = tx.
+			Table(table).
+			Where("id = ? AND deleted_at IS NULL", report.TargetId).
+			Update("deleted_at", now).
+			Error
+		if err == nil && report.TargetType == entity.ReportTargetType_Card {
+			err = tx.
+				Table("collection_cards").
+				Where("card_id = ? AND deleted_at IS NULL", report.TargetId).
+				Update("deleted_at", now).
+				Error
+		}
+	case entity.ModerationAction_Warn:
+		warning := UserWarning{
+			Id:         uuid.New(),
+			UserId:     report.AuthorId,
+			ReportId:   report.Id,
+			TargetType: string(report.TargetType),
+			TargetId:   report.TargetId,
+			Reason:     string(report.Reason),
+			Note:       note,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		err = tx.Table("user_warning").Create(&warning).Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.
+		Table("report").
+		Where("target_type = ? AND target_id = ? AND status = ? AND deleted_at IS NULL",
+			report.TargetType, report.TargetId, entity.ReportStatus_Open).
+		Updates(map[string]interface{}{
+			"status":       entity.ReportStatus_Resolved,
+			"action":       action,
+			"moderator_id": moderatorId,
+			"note":         note,
+			"resolved_at":  now,
+			"updated_at":   now,
+		}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *repository) GetUserWarnings(userId uuid.UUID) ([]*entity.UserWarning, error) {
+	datas := []*UserWarning{}
+	err := r.db.
+		Table("user_warning").
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Order("created_at DESC").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.UserWarning{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
@@ -6,6 +6,11 @@ import (
 	cardRepo "github.com/flash-cards-vocab/backend/pkg/repository/card_repository"
 	collectionRepo "github.com/flash-cards-vocab/backend/pkg/repository/collection_repository"
 	companyRepo "github.com/flash-cards-vocab/backend/pkg/repository/company_repository"
+	folderRepo "github.com/flash-cards-vocab/backend/pkg/repository/folder_repository"
+	importJobRepo "github.com/flash-cards-vocab/backend/pkg/repository/import_job_repository"
+	lexemeRepo "github.com/flash-cards-vocab/backend/pkg/repository/lexeme_repository"
+	moderationRepo "github.com/flash-cards-vocab/backend/pkg/repository/moderation_repository"
+	userExportRepo "github.com/flash-cards-vocab/backend/pkg/repository/user_export_repository"
 	userRepo "github.com/flash-cards-vocab/backend/pkg/repository/user_repository"
 )
 
@@ -14,6 +19,11 @@ type Repository struct {
 	CollectionRepository repositoryIntf.CollectionRepository
 	UserRepository       repositoryIntf.UserRepository
 	CompanyRepository    repositoryIntf.CompanyRepository
+	FolderRepository     repositoryIntf.FolderRepository
+	ModerationRepository repositoryIntf.ModerationRepository
+	UserExportRepository repositoryIntf.UserExportRepository
+	ImportJobRepository  repositoryIntf.ImportJobRepository
+	LexemeRepository     repositoryIntf.LexemeRepository
 }
 
 func Get(app *application.Application) *Repository {
@@ -21,11 +31,21 @@ func Get(app *application.Application) *Repository {
 	collectionRepository := collectionRepo.New(app.DBManager.DB)
 	userRepository := userRepo.New(app.DBManager.DB)
 	companyRepository := companyRepo.New(app.DBManager.DB)
+	folderRepository := folderRepo.New(app.DBManager.DB)
+	moderationRepository := moderationRepo.New(app.DBManager.DB)
+	userExportRepository := userExportRepo.New(app.DBManager.DB)
+	importJobRepository := importJobRepo.New(app.DBManager.DB)
+	lexemeRepository := lexemeRepo.New(app.DBManager.DB)
 
 	return &Repository{
 		CardRepository:       cardRepository,
 		CollectionRepository: collectionRepository,
 		UserRepository:       userRepository,
 		CompanyRepository:    companyRepository,
+		FolderRepository:     folderRepository,
+		ModerationRepository: moderationRepository,
+		UserExportRepository: userExportRepository,
+		ImportJobRepository:  importJobRepository,
+		LexemeRepository:     lexemeRepository,
 	}
 }
@@ -0,0 +1,287 @@
+package user_export_repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type UserDataExport struct {
+	Id          uuid.UUID  `gorm:"primary_key;column:id"`
+	UserId      uuid.UUID  `gorm:"column:user_id"`
+	Status      string     `gorm:"column:status"`
+	ObjectName  string     `gorm:"column:object_name"`
+	Error       string     `gorm:"column:error"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+	DeletedAt   *time.Time `gorm:"column:deleted_at"`
+}
+
+func (u *UserDataExport) ToEntity() *entity.UserDataExport {
+	return &entity.UserDataExport{
+		Id:          u.Id,
+		UserId:      u.UserId,
+		Status:      entity.UserDataExportStatus(u.Status),
+		ObjectName:  u.ObjectName,
+		Error:       u.Error,
+		CreatedAt:   u.CreatedAt,
+		CompletedAt: u.CompletedAt,
+	}
+}
+
+type Collection struct {
+	Id             uuid.UUID      `gorm:"primary_key;column:id"`
+	Name           string         `gorm:"column:name"`
+	AuthorId       uuid.UUID      `gorm:"column:author_id"`
+	SourceLanguage string         `gorm:"column:source_language"`
+	TargetLanguage string         `gorm:"column:target_language"`
+	Level          string         `gorm:"column:level"`
+	Topics         pq.StringArray `gorm:"type:text[];column:topics"`
+	Hidden         bool           `gorm:"column:hidden"`
+	CreatedAt      time.Time      `gorm:"column:created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at"`
+}
+
+func (c *Collection) ToEntity() *entity.UserDataCollection {
+	return &entity.UserDataCollection{
+		Collection: entity.Collection{
+			Id:             c.Id,
+			Name:           c.Name,
+			Topics:         c.Topics,
+			AuthorId:       c.AuthorId,
+			SourceLanguage: c.SourceLanguage,
+			TargetLanguage: c.TargetLanguage,
+			Level:          c.Level,
+			Hidden:         c.Hidden,
+			CreatedAt:      c.CreatedAt,
+			UpdatedAt:      c.UpdatedAt,
+		},
+		CardIds: []uuid.UUID{},
+	}
+}
+
+type CollectionCard struct {
+	CollectionId uuid.UUID `gorm:"column:collection_id"`
+	CardId       uuid.UUID `gorm:"column:card_id"`
+}
+
+type Card struct {
+	Id             uuid.UUID `gorm:"primary_key;column:id"`
+	Word           string    `gorm:"column:word"`
+	ImageUrl       string    `gorm:"column:image_url"`
+	AudioUrl       string    `gorm:"column:audio_url"`
+	Definition     string    `gorm:"column:definition"`
+	Sentence       string    `gorm:"column:sentence"`
+	Antonyms       string    `gorm:"column:antonyms"`
+	Synonyms       string    `gorm:"column:synonyms"`
+	SynonymList    string    `gorm:"column:synonym_list"`
+	AntonymList    string    `gorm:"column:antonym_list"`
+	Senses         string    `gorm:"column:senses"`
+	Pronunciation  string    `gorm:"column:pronunciation"`
+	Gender         string    `gorm:"column:gender"`
+	Plural         string    `gorm:"column:plural"`
+	AuthorId       uuid.UUID `gorm:"column:author_id"`
+	SourceLanguage string    `gorm:"column:source_language"`
+	TargetLanguage string    `gorm:"column:target_language"`
+	Level          string    `gorm:"column:level"`
+}
+
+func (c *Card) ToEntity() *entity.Card {
+	return &entity.Card{
+		Id:             c.Id,
+		Word:           c.Word,
+		ImageUrl:       c.ImageUrl,
+		AudioUrl:       c.AudioUrl,
+		Definition:     c.Definition,
+		Sentence:       c.Sentence,
+		Antonyms:       c.Antonyms,
+		Synonyms:       c.Synonyms,
+		SynonymList:    relatedWordsEntity(c.SynonymList, c.Synonyms),
+		AntonymList:    relatedWordsEntity(c.AntonymList, c.Antonyms),
+		Senses:         sensesEntity(c.Senses),
+		Pronunciation:  c.Pronunciation,
+		Gender:         c.Gender,
+		Plural:         c.Plural,
+		AuthorId:       c.AuthorId,
+		SourceLanguage: c.SourceLanguage,
+		TargetLanguage: c.TargetLanguage,
+		Level:          c.Level,
+	}
+}
+
+type Folder struct {
+	Id       uuid.UUID  `gorm:"primary_key;column:id"`
+	Name     string     `gorm:"column:name"`
+	ParentId *uuid.UUID `gorm:"column:parent_id"`
+	UserId   uuid.UUID  `gorm:"column:user_id"`
+}
+
+func (f *Folder) ToEntity() *entity.Folder {
+	return &entity.Folder{
+		Id:       f.Id,
+		Name:     f.Name,
+		ParentId: f.ParentId,
+		UserId:   f.UserId,
+	}
+}
+
+type FolderCollection struct {
+	FolderId     uuid.UUID `gorm:"column:folder_id"`
+	CollectionId uuid.UUID `gorm:"column:collection_id"`
+	Name         string    `gorm:"column:name"`
+}
+
+func (f *FolderCollection) ToEntity() *entity.UserDataFolderCollection {
+	return &entity.UserDataFolderCollection{
+		FolderId:     f.FolderId,
+		CollectionId: f.CollectionId,
+		Name:         f.Name,
+	}
+}
+
+type CollectionUserMetrics struct {
+	Id           uuid.UUID `gorm:"primary_key;column:id"`
+	UserId       uuid.UUID `gorm:"column:user_id"`
+	CollectionId uuid.UUID `gorm:"column:collection_id"`
+	Liked        bool      `gorm:"column:liked"`
+	Disliked     bool      `gorm:"column:disliked"`
+	Viewed       bool      `gorm:"column:viewed"`
+	Starred      bool      `gorm:"column:starred"`
+}
+
+func (c *CollectionUserMetrics) ToEntity() *entity.CollectionUserMetrics {
+	return &entity.CollectionUserMetrics{
+		Id:           c.Id,
+		UserId:       c.UserId,
+		CollectionId: c.CollectionId,
+		Liked:        c.Liked,
+		Disliked:     c.Disliked,
+		Viewed:       c.Viewed,
+		Starred:      c.Starred,
+	}
+}
+
+type CardUserMetrics struct {
+	Id       uuid.UUID `gorm:"primary_key;column:id"`
+	UserId   uuid.UUID `gorm:"column:user_id"`
+	CardId   uuid.UUID `gorm:"column:card_id"`
+	Liked    bool      `gorm:"column:liked"`
+	Disliked bool      `gorm:"column:disliked"`
+}
+
+func (c *CardUserMetrics) ToEntity() *entity.CardUserMetrics {
+	return &entity.CardUserMetrics{
+		Id:       c.Id,
+		UserId:   c.UserId,
+		CardId:   c.CardId,
+		Liked:    c.Liked,
+		Disliked: c.Disliked,
+	}
+}
+
+type CollectionUserProgress struct {
+	Id           uuid.UUID `gorm:"primary_key;column:id"`
+	CollectionId uuid.UUID `gorm:"column:collection_id"`
+	UserId       uuid.UUID `gorm:"column:user_id"`
+	Mastered     uint32    `gorm:"column:mastered"`
+	Reviewing    uint32    `gorm:"column:reviewing"`
+	Learning     uint32    `gorm:"column:learning"`
+}
+
+func (c *CollectionUserProgress) ToEntity() *entity.CollectionUserProgress {
+	return &entity.CollectionUserProgress{
+		Id:           c.Id,
+		CollectionId: c.CollectionId,
+		UserId:       c.UserId,
+		Mastered:     c.Mastered,
+		Reviewing:    c.Reviewing,
+		Learning:     c.Learning,
+	}
+}
+
+type CardUserProgress struct {
+	Id     uuid.UUID                   `gorm:"primary_key;column:id"`
+	CardId uuid.UUID                   `gorm:"column:card_id"`
+	UserId uuid.UUID                   `gorm:"column:user_id"`
+	Status entity.CardUserProgressType `gorm:"column:status"`
+}
+
+func (c *CardUserProgress) ToEntity() *entity.CardUserProgress {
+	return &entity.CardUserProgress{
+		Id:     c.Id,
+		CardId: c.CardId,
+		UserId: c.UserId,
+		Status: c.Status,
+	}
+}
+
+type CardNote struct {
+	Id        uuid.UUID `gorm:"primary_key;column:id"`
+	CardId    uuid.UUID `gorm:"column:card_id"`
+	UserId    uuid.UUID `gorm:"column:user_id"`
+	Note      string    `gorm:"column:note"`
+	Mnemonic  string    `gorm:"column:mnemonic"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (c *CardNote) ToEntity() *entity.CardNote {
+	return &entity.CardNote{
+		Id:        c.Id,
+		CardId:    c.CardId,
+		UserId:    c.UserId,
+		Note:      c.Note,
+		Mnemonic:  c.Mnemonic,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+type CardMnemonic struct {
+	Id        uuid.UUID `gorm:"primary_key;column:id"`
+	CardId    uuid.UUID `gorm:"column:card_id"`
+	AuthorId  uuid.UUID `gorm:"column:author_id"`
+	Text      string    `gorm:"column:text"`
+	Upvotes   uint32    `gorm:"column:upvotes"`
+	Downvotes uint32    `gorm:"column:downvotes"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (c *CardMnemonic) ToEntity() *entity.CardMnemonic {
+	return &entity.CardMnemonic{
+		Id:        c.Id,
+		CardId:    c.CardId,
+		AuthorId:  c.AuthorId,
+		Text:      c.Text,
+		Upvotes:   c.Upvotes,
+		Downvotes: c.Downvotes,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// sensesEntity reads the senses stored as JSON, a card without senses stores an empty string
+func sensesEntity(column string) []*entity.CardSense {
+	if column == "" {
+		return nil
+	}
+	senses := []*entity.CardSense{}
+	if err := json.Unmarshal([]byte(column), &senses); err != nil {
+		return nil
+	}
+	return senses
+}
+
+// relatedWordsEntity reads the synonyms or antonyms stored as JSON, cards stored before the lists
+// and by old clients only have the flat text
+func relatedWordsEntity(column, flat string) []*entity.RelatedWord {
+	if column == "" {
+		return entity.ParseRelatedWords(flat, nil)
+	}
+	words := []*entity.RelatedWord{}
+	if err := json.Unmarshal([]byte(column), &words); err != nil {
+		return entity.ParseRelatedWords(flat, nil)
+	}
+	return words
+}
@@ -0,0 +1,286 @@
+package user_export_repository
+
+import (
+	"errors"
+	"time"
+
+	repositoryIntf "github.com/flash-cards-vocab/backend/app/repository"
+	"github.com/flash-cards-vocab/backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var unfinishedUserExportStatuses = []string{
+	string(entity.UserDataExportStatus_Pending),
+	string(entity.UserDataExportStatus_Running),
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) repositoryIntf.UserExportRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateUserExport(export entity.UserDataExport) (*entity.UserDataExport, error) {
+	exportModel := UserDataExport{
+		Id:        uuid.New(),
+		UserId:    export.UserId,
+		Status:    string(entity.UserDataExportStatus_Pending),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	err := r.db.Table("user_data_export").Create(&exportModel).Error
+	if err != nil {
+		return nil, err
+	}
+	return exportModel.ToEntity(), nil
+}
+
+func (r *repository) UpdateUserExport(export entity.UserDataExport) error {
+	return r.db.
+		Table("user_data_export").
+		Where("id = ? AND deleted_at IS NULL", export.Id).
+		Updates(map[string]interface{}{
+			"status":       string(export.Status),
+			"object_name":  export.ObjectName,
+			"error":        export.Error,
+			"completed_at": export.CompletedAt,
+			"updated_at":   time.Now(),
+		}).
+		Error
+}
+
+func (r *repository) GetUserExport(id uuid.UUID) (*entity.UserDataExport, error) {
+	data := UserDataExport{}
+	err := r.db.
+		Table("user_data_export").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&data).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositoryIntf.ErrUserExportNotFound
+		}
+		return nil, err
+	}
+	return data.ToEntity(), nil
+}
+
+func (r *repository) GetUnfinishedUserExports() ([]*entity.UserDataExport, error) {
+	datas := []*UserDataExport{}
+	err := r.db.
+		Table("user_data_export").
+		Where("status IN ? AND deleted_at IS NULL", unfinishedUserExportStatuses).
+		Order("created_at").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.UserDataExport{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+// GetUserAuthoredCollections returns the collections created by the user with the ids of their cards
+func (r *repository) GetUserAuthoredCollections(userId uuid.UUID) ([]*entity.UserDataCollection, error) {
+	datas := []*Collection{}
+	err := r.db.
+		Table("collection").
+		Where("author_id = ? AND deleted_at IS NULL", userId).
+		Order("created_at").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	collectionCards := []*CollectionCard{}
+	err = r.db.
+		Table("collection_cards AS cc").
+		Select("cc.collection_id, cc.card_id").
+		Joins("INNER JOIN collection ON collection.id = cc.collection_id").
+		Joins("INNER JOIN card ON card.id = cc.card_id").
+		Where("collection.author_id = ? AND collection.deleted_at IS NULL AND cc.deleted_at IS NULL AND card.deleted_at IS NULL", userId).
+		Order("cc.created_at").
+		Scan(&collectionCards).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	resp := []*entity.UserDataCollection{}
+	collections := map[uuid.UUID]*entity.UserDataCollection{}
+	for _, data := range datas {
+		collection := data.ToEntity()
+		collections[collection.Id] = collection
+		resp = append(resp, collection)
+	}
+	for _, collectionCard := range collectionCards {
+		if collection, ok := collections[collectionCard.CollectionId]; ok {
+			collection.CardIds = append(collection.CardIds, collectionCard.CardId)
+		}
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserAuthoredCards(userId uuid.UUID) ([]*entity.Card, error) {
+	datas := []*Card{}
+	err := r.db.
+		Table("card").
+		Where("author_id = ? AND deleted_at IS NULL", userId).
+		Order("created_at").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.Card{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserFolders(userId uuid.UUID) ([]*entity.Folder, error) {
+	datas := []*Folder{}
+	err := r.db.
+		Table("folder").
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Order("name").
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.Folder{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserFolderCollections(userId uuid.UUID) ([]*entity.UserDataFolderCollection, error) {
+	datas := []*FolderCollection{}
+	err := r.db.
+		Table("folder_collections AS fc").
+		Select("fc.folder_id, fc.collection_id, collection.name").
+		Joins("INNER JOIN collection ON collection.id = fc.collection_id").
+		Where("fc.user_id = ? AND fc.deleted_at IS NULL AND collection.deleted_at IS NULL", userId).
+		Order("collection.name").
+		Scan(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.UserDataFolderCollection{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserCollectionMetrics(userId uuid.UUID) ([]*entity.CollectionUserMetrics, error) {
+	datas := []*CollectionUserMetrics{}
+	err := r.db.
+		Table("collection_user_metrics").
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CollectionUserMetrics{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserCollectionProgress(userId uuid.UUID) ([]*entity.CollectionUserProgress, error) {
+	datas := []*CollectionUserProgress{}
+	err := r.db.
+		Table("collection_user_progress").
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CollectionUserProgress{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserCardProgress(userId uuid.UUID) ([]*entity.CardUserProgress, error) {
+	datas := []*CardUserProgress{}
+	err := r.db.
+		Table("card_user_progress").
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CardUserProgress{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserCardNotes(userId uuid.UUID) ([]*entity.CardNote, error) {
+	datas := []*CardNote{}
+	err := r.db.
+		Table("card_note").
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CardNote{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserCardMnemonics(userId uuid.UUID) ([]*entity.CardMnemonic, error) {
+	datas := []*CardMnemonic{}
+	err := r.db.
+		Table("card_mnemonic").
+		Where("author_id = ? AND deleted_at IS NULL", userId).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CardMnemonic{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
+
+func (r *repository) GetUserCardMetrics(userId uuid.UUID) ([]*entity.CardUserMetrics, error) {
+	datas := []*CardUserMetrics{}
+	err := r.db.
+		Table("card_user_metrics").
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Find(&datas).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	resp := []*entity.CardUserMetrics{}
+	for _, data := range datas {
+		resp = append(resp, data.ToEntity())
+	}
+	return resp, nil
+}
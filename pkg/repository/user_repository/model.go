@@ -13,6 +13,7 @@ type User struct {
 	Username  string     `gorm:"column:username"`
 	Email     string     `gorm:"column:email"`
 	Password  string     `gorm:"column:password"`
+	Role      string     `gorm:"column:role"`
 	CreatedAt time.Time  `gorm:"column:created_at"`
 	UpdatedAt time.Time  `gorm:"column:updated_at"`
 	DeletedAt *time.Time `gorm:"column:deleted_at"`
@@ -25,5 +26,6 @@ func (u *User) ToEntity() *entity.User {
 		Username: u.Username,
 		Email:    u.Email,
 		Password: u.Password,
+		Role:     entity.UserRole(u.Role),
 	}
 }
@@ -27,6 +27,7 @@ func (r *repository) CreateUser(user entity.User) (*entity.User, error) {
 		Username:  user.Username,
 		Email:     user.Email,
 		Password:  user.Password,
+		Role:      string(entity.UserRole_User),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
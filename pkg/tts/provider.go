@@ -0,0 +1,37 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedLanguage = errors.New("language is not supported by the speech provider")
+var ErrUnknownProvider = errors.New("unknown speech provider")
+
+const (
+	// ProviderStub is the local tone provider of NewStub
+	ProviderStub = "stub"
+	// stubEnvironment is the only environment the stub provider may run in
+	stubEnvironment = "dev"
+)
+
+// Provider reads a text aloud, the returned audio must be MP3, OGG or WAV
+type Provider interface {
+	// Synthesize returns the audio of the text spoken in the language, a BCP-47 tag that may be empty
+	Synthesize(ctx context.Context, text, language string) ([]byte, error)
+}
+
+// New returns the configured speech provider, or nil when name is empty and speech is unavailable
+func New(name, environment string) (Provider, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case ProviderStub:
+		if environment != stubEnvironment {
+			return nil, fmt.Errorf("%w: %s is only allowed in the %s environment", ErrUnknownProvider, name, stubEnvironment)
+		}
+		return NewStub(), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+}
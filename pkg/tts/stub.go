@@ -0,0 +1,60 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	stubSampleRate  = 8000
+	stubToneHz      = 440
+	stubPerRune     = time.Millisecond * 80
+	stubMinDuration = time.Millisecond * 300
+	stubMaxDuration = time.Second * 5
+)
+
+// stub is a local provider for development and tests, it returns a short tone
+// as long as it would take to read the text instead of speech
+type stub struct{}
+
+func NewStub() Provider {
+	return &stub{}
+}
+
+func (s *stub) Synthesize(ctx context.Context, text, language string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	duration := time.Duration(utf8.RuneCountInString(text)) * stubPerRune
+	if duration < stubMinDuration {
+		duration = stubMinDuration
+	}
+	if duration > stubMaxDuration {
+		duration = stubMaxDuration
+	}
+	samples := int(duration * stubSampleRate / time.Second)
+
+	buf := &bytes.Buffer{}
+	// 8-bit mono PCM WAV
+	buf.WriteString("RIFF")
+	binary.Write(buf, binary.LittleEndian, uint32(36+samples))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(buf, binary.LittleEndian, uint32(16))
+	binary.Write(buf, binary.LittleEndian, uint16(1))
+	binary.Write(buf, binary.LittleEndian, uint16(1))
+	binary.Write(buf, binary.LittleEndian, uint32(stubSampleRate))
+	binary.Write(buf, binary.LittleEndian, uint32(stubSampleRate))
+	binary.Write(buf, binary.LittleEndian, uint16(1))
+	binary.Write(buf, binary.LittleEndian, uint16(8))
+	buf.WriteString("data")
+	binary.Write(buf, binary.LittleEndian, uint32(samples))
+	for i := 0; i < samples; i++ {
+		value := math.Sin(2 * math.Pi * stubToneHz * float64(i) / stubSampleRate)
+		buf.WriteByte(byte(128 + 40*value))
+	}
+	return buf.Bytes(), nil
+}